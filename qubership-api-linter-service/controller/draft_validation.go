@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+type DraftValidationController interface {
+	ValidateDraft(w http.ResponseWriter, r *http.Request)
+	GetDraftValidationSummary(w http.ResponseWriter, r *http.Request)
+	GetDraftValidationResultForDocument(w http.ResponseWriter, r *http.Request)
+}
+
+func NewDraftValidationController(draftValidationService service.DraftValidationService, authorizationService service.AuthorizationService) DraftValidationController {
+	return &draftValidationControllerImpl{draftValidationService: draftValidationService, authorizationService: authorizationService}
+}
+
+type draftValidationControllerImpl struct {
+	draftValidationService service.DraftValidationService
+	authorizationService   service.AuthorizationService
+}
+
+func (d draftValidationControllerImpl) ValidateDraft(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := d.authorizationService.HasPublishPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = r.ParseMultipartForm(1024 * 1024)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	defer func() {
+		err := r.MultipartForm.RemoveAll()
+		if err != nil {
+			log.Debugf("failed to remove temporal data: %+v", err)
+		}
+	}()
+
+	fileHeaders := r.MultipartForm.File["files"]
+	if len(fileHeaders) == 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "files"},
+		})
+		return
+	}
+
+	var docs []view.DraftDocument
+	for _, fileHeader := range fileHeaders {
+		file, err := fileHeader.Open()
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectMultipartFile,
+				Message: exception.IncorrectMultipartFileMsg,
+				Debug:   err.Error()})
+			return
+		}
+		data, err := io.ReadAll(file)
+		closeErr := file.Close()
+		if closeErr != nil {
+			log.Debugf("failed to close temporal file: %+v", closeErr)
+		}
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectMultipartFile,
+				Message: exception.IncorrectMultipartFileMsg,
+				Debug:   err.Error()})
+			return
+		}
+		docs = append(docs, view.DraftDocument{FileName: fileHeader.Filename, Data: data})
+	}
+
+	result, err := d.draftValidationService.ValidateDraft(ctx, packageId, docs)
+	if err != nil {
+		respondWithError(w, "Failed to validate draft", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (d draftValidationControllerImpl) GetDraftValidationSummary(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := d.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	draftId := getStringParam(r, "draftId")
+
+	result, err := d.draftValidationService.GetDraftSummary(ctx, packageId, draftId)
+	if err != nil {
+		respondWithError(w, "Failed to get draft validation summary", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (d draftValidationControllerImpl) GetDraftValidationResultForDocument(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := d.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	draftId := getStringParam(r, "draftId")
+	slug := getStringParam(r, "slug")
+
+	result, err := d.draftValidationService.GetDraftDocumentResult(ctx, packageId, draftId, slug)
+	if err != nil {
+		respondWithError(w, "Failed to get draft validation result for document", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+type LintController interface {
+	LintDocument(w http.ResponseWriter, r *http.Request)
+}
+
+func NewLintController(lintService service.LintService, authorizationService service.AuthorizationService) LintController {
+	return &lintControllerImpl{lintService: lintService, authorizationService: authorizationService}
+}
+
+type lintControllerImpl struct {
+	lintService          service.LintService
+	authorizationService service.AuthorizationService
+}
+
+func (l lintControllerImpl) LintDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := l.authorizationService.HasAdHocLintPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = r.ParseMultipartForm(1024 * 1024)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	defer func() {
+		err := r.MultipartForm.RemoveAll()
+		if err != nil {
+			log.Debugf("failed to remove temporal data: %+v", err)
+		}
+	}()
+
+	data, fileName, customErr := readMultipartFile(r, "file")
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	if data == nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "file"},
+		})
+		return
+	}
+
+	req := view.AdHocLintRequest{
+		FileName:  fileName,
+		Data:      data,
+		RulesetId: r.FormValue("rulesetId"),
+	}
+
+	if apiTypeStr := r.FormValue("apiType"); apiTypeStr != "" {
+		req.ApiType = view.ApiType(apiTypeStr)
+		err = validateApiType(req.ApiType)
+		if err != nil {
+			respondWithError(w, "incorrect api type", err)
+			return
+		}
+	}
+
+	rulesetData, rulesetFileName, customErr := readMultipartFile(r, "rulesetFile")
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	if rulesetData != nil {
+		if req.RulesetId != "" {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.MutuallyExclusiveParams,
+				Message: exception.MutuallyExclusiveParamsMsg,
+				Params:  map[string]interface{}{"params": "rulesetId, rulesetFile"},
+			})
+			return
+		}
+		// inline ruleset may execute custom functions and resolve extends like a stored ruleset, so it requires the same permission
+		sufficientPrivileges, err = l.authorizationService.HasRulesetManagementPermission(ctx)
+		if err != nil {
+			respondWithError(w, "Failed to check permissions", err)
+			return
+		}
+		if !sufficientPrivileges {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusForbidden,
+				Code:    exception.InsufficientPrivileges,
+				Message: exception.InsufficientPrivilegesMsg,
+			})
+			return
+		}
+		req.Ruleset = &view.InlineRuleset{
+			Linter:   view.Linter(r.FormValue("linter")),
+			FileName: rulesetFileName,
+			Data:     rulesetData,
+		}
+	}
+
+	result, err := l.lintService.LintDocument(ctx, req)
+	if err != nil {
+		respondWithError(w, "Failed to lint document", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+// readMultipartFile returns nil data if the file is not present in the form
+func readMultipartFile(r *http.Request, name string) ([]byte, string, *exception.CustomError) {
+	file, fileHeader, err := r.FormFile(name)
+	if err != nil {
+		if err == http.ErrMissingFile {
+			return nil, "", nil
+		}
+		return nil, "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.IncorrectMultipartFile,
+			Message: exception.IncorrectMultipartFileMsg,
+			Debug:   err.Error()}
+	}
+	data, err := io.ReadAll(file)
+	closeErr := file.Close()
+	if closeErr != nil {
+		log.Debugf("failed to close temporal file: %+v", closeErr)
+	}
+	if err != nil {
+		return nil, "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.IncorrectMultipartFile,
+			Message: exception.IncorrectMultipartFileMsg,
+			Debug:   err.Error()}
+	}
+	return data, fileHeader.Filename, nil
+}
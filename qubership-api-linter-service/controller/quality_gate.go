@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type QualityGateController interface {
+	GetRulesetQualityGate(w http.ResponseWriter, r *http.Request)
+	SetRulesetQualityGate(w http.ResponseWriter, r *http.Request)
+	DeleteRulesetQualityGate(w http.ResponseWriter, r *http.Request)
+	GetPackageQualityGate(w http.ResponseWriter, r *http.Request)
+	SetPackageQualityGate(w http.ResponseWriter, r *http.Request)
+	DeletePackageQualityGate(w http.ResponseWriter, r *http.Request)
+}
+
+func NewQualityGateController(qualityGateService service.QualityGateService, authorizationService service.AuthorizationService) QualityGateController {
+	return &qualityGateControllerImpl{qualityGateService: qualityGateService, authorizationService: authorizationService}
+}
+
+type qualityGateControllerImpl struct {
+	qualityGateService   service.QualityGateService
+	authorizationService service.AuthorizationService
+}
+
+func (c qualityGateControllerImpl) GetRulesetQualityGate(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetReadPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.qualityGateService.GetRulesetQualityGate(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to get ruleset quality gate", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c qualityGateControllerImpl) SetRulesetQualityGate(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetManagementPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	policy, customErr := readQualityGatePolicy(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := c.qualityGateService.SetRulesetQualityGate(ctx, rulesetId, *policy)
+	if err != nil {
+		respondWithError(w, "Failed to set ruleset quality gate", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c qualityGateControllerImpl) DeleteRulesetQualityGate(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetManagementPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = c.qualityGateService.DeleteRulesetQualityGate(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to delete ruleset quality gate", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c qualityGateControllerImpl) GetPackageQualityGate(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.qualityGateService.GetPackageQualityGate(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to get package quality gate", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c qualityGateControllerImpl) SetPackageQualityGate(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetBindingManagementPermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	policy, customErr := readQualityGatePolicy(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := c.qualityGateService.SetPackageQualityGate(ctx, packageId, *policy)
+	if err != nil {
+		respondWithError(w, "Failed to set package quality gate", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c qualityGateControllerImpl) DeletePackageQualityGate(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetBindingManagementPermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = c.qualityGateService.DeletePackageQualityGate(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to delete package quality gate", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func readQualityGatePolicy(r *http.Request) (*view.QualityGatePolicy, *exception.CustomError) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		}
+	}
+	var policy view.QualityGatePolicy
+	err = json.Unmarshal(body, &policy)
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		}
+	}
+	return &policy, nil
+}
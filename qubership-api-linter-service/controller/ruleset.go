@@ -16,13 +16,16 @@ package controller
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/Netcracker/qubership-api-linter-service/exception"
 	"github.com/Netcracker/qubership-api-linter-service/secctx"
 	"github.com/Netcracker/qubership-api-linter-service/service"
 	"github.com/Netcracker/qubership-api-linter-service/view"
 	log "github.com/sirupsen/logrus"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"path/filepath"
 	"strconv"
@@ -32,22 +35,40 @@ import (
 type RulesetController interface {
 	CreateRuleset(w http.ResponseWriter, r *http.Request)
 	ActivateRuleset(w http.ResponseWriter, r *http.Request)
+	CancelScheduledActivation(w http.ResponseWriter, r *http.Request)
+	RollbackActivation(w http.ResponseWriter, r *http.Request)
 	ListRulesets(w http.ResponseWriter, r *http.Request)
 	GetRuleset(w http.ResponseWriter, r *http.Request)
 	GetRulesetData(w http.ResponseWriter, r *http.Request)
 	GetRulesetActivationHistory(w http.ResponseWriter, r *http.Request)
 	DeleteRuleset(w http.ResponseWriter, r *http.Request)
+	DryRunRuleset(w http.ResponseWriter, r *http.Request)
+	ListRulesetRevisions(w http.ResponseWriter, r *http.Request)
+	GetRulesetDiff(w http.ResponseWriter, r *http.Request)
+	GetRuleCatalog(w http.ResponseWriter, r *http.Request)
+	ListRelintCampaigns(w http.ResponseWriter, r *http.Request)
+	GetRelintCampaign(w http.ResponseWriter, r *http.Request)
 }
 
 type rulesetControllerImpl struct {
-	rulesetService       service.RulesetService
-	authorizationService service.AuthorizationService
+	rulesetService        service.RulesetService
+	rulesetDryRunService  service.RulesetDryRunService
+	ruleCatalogService    service.RuleCatalogService
+	relintCampaignService service.RelintCampaignService
+	authorizationService  service.AuthorizationService
+	linterRegistry        service.LinterRegistry
 }
 
-func NewRulesetController(rulesetService service.RulesetService, authorizationService service.AuthorizationService) RulesetController {
+func NewRulesetController(rulesetService service.RulesetService, rulesetDryRunService service.RulesetDryRunService,
+	ruleCatalogService service.RuleCatalogService, relintCampaignService service.RelintCampaignService,
+	authorizationService service.AuthorizationService, linterRegistry service.LinterRegistry) RulesetController {
 	return &rulesetControllerImpl{
-		rulesetService:       rulesetService,
-		authorizationService: authorizationService,
+		rulesetService:        rulesetService,
+		rulesetDryRunService:  rulesetDryRunService,
+		ruleCatalogService:    ruleCatalogService,
+		relintCampaignService: relintCampaignService,
+		authorizationService:  authorizationService,
+		linterRegistry:        linterRegistry,
 	}
 }
 
@@ -124,7 +145,7 @@ func (c rulesetControllerImpl) CreateRuleset(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	linter := view.Linter(linterStr)
-	err = validateLinter(linter)
+	err = c.validateLinter(linter)
 	if err != nil {
 		respondWithError(w, "incorrect linter", err)
 		return
@@ -177,7 +198,57 @@ func (c rulesetControllerImpl) CreateRuleset(w http.ResponseWriter, r *http.Requ
 		data = data[:len]
 	}
 
-	result, err := c.rulesetService.CreateRuleset(ctx, name, apiType, linter, fileHeader.Filename, data)
+	// parents could be passed as several form values or as a comma separated list
+	var parents []string
+	for _, value := range r.MultipartForm.Value["parents"] {
+		for _, parent := range strings.Split(value, ",") {
+			parent = strings.TrimSpace(parent)
+			if parent != "" {
+				parents = append(parents, parent)
+			}
+		}
+	}
+
+	functionFiles := make(map[string][]byte)
+	for _, functionFileHeader := range r.MultipartForm.File["functionFiles"] {
+		functionData, err := readFileHeader(functionFileHeader)
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectMultipartFile,
+				Message: exception.IncorrectMultipartFileMsg,
+				Debug:   err.Error()})
+			return
+		}
+		functionFiles[functionFileHeader.Filename] = functionData
+	}
+
+	newRevision := false
+	if r.FormValue("newRevision") != "" {
+		newRevision, err = strconv.ParseBool(r.FormValue("newRevision"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "newRevision", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := c.rulesetService.CreateRuleset(ctx, view.CreateRulesetRequest{
+		Name:          name,
+		ApiType:       apiType,
+		Linter:        linter,
+		FileName:      fileHeader.Filename,
+		Data:          data,
+		MainFile:      r.FormValue("mainFile"),
+		Parents:       parents,
+		FunctionFiles: functionFiles,
+		NewRevision:   newRevision,
+	})
 	if err != nil {
 		respondWithError(w, "Failed to create ruleset", err)
 		return
@@ -203,15 +274,107 @@ func (c rulesetControllerImpl) ActivateRuleset(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = c.rulesetService.ActivateRuleset(ctx, rulesetId)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	// the body is optional, the ruleset is activated immediately without it
+	var req view.ActivateRulesetRequest
+	if len(strings.TrimSpace(string(body))) > 0 {
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BadRequestBody,
+				Message: exception.BadRequestBodyMsg,
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	if req.ActivateAt != nil {
+		schedule, err := c.rulesetService.ScheduleActivation(ctx, rulesetId, *req.ActivateAt, req.Relint)
+		if err != nil {
+			respondWithError(w, "Failed to schedule ruleset activation", err)
+			return
+		}
+		respondWithJson(w, http.StatusAccepted, schedule)
+		return
+	}
+
+	campaign, err := c.rulesetService.ActivateRuleset(ctx, rulesetId, req.Relint)
 	if err != nil {
 		respondWithError(w, "Failed to activate ruleset", err)
 		return
 	}
+	if campaign != nil {
+		respondWithJson(w, http.StatusOK, campaign)
+		return
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (c rulesetControllerImpl) CancelScheduledActivation(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetManagementPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = c.rulesetService.CancelScheduledActivation(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to cancel scheduled ruleset activation", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c rulesetControllerImpl) RollbackActivation(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetManagementPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.rulesetService.RollbackActivation(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to rollback ruleset activation", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
 func (c rulesetControllerImpl) ListRulesets(w http.ResponseWriter, r *http.Request) {
 	ctx := secctx.MakeUserContext(r)
 	sufficientPrivileges, err := c.authorizationService.HasRulesetListPermission(ctx)
@@ -332,6 +495,8 @@ func (c rulesetControllerImpl) GetRulesetData(w http.ResponseWriter, r *http.Req
 		contentType = "application/json"
 	case ".yml", ".yaml":
 		contentType = "application/yaml"
+	case ".zip":
+		contentType = "application/zip"
 	}
 
 	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=\"%s\"", disposition, filename))
@@ -360,17 +525,12 @@ func (c rulesetControllerImpl) GetRulesetActivationHistory(w http.ResponseWriter
 		return
 	}
 
-	records, err := c.rulesetService.GetActivationHistory(ctx, rulesetId)
+	result, err := c.rulesetService.GetActivationHistory(ctx, rulesetId)
 	if err != nil {
 		respondWithError(w, "Failed to get activation history", err)
 		return
 	}
 
-	result := view.ActivationHistoryResponse{
-		Id:                rulesetId,
-		ActivationHistory: records,
-	}
-
 	respondWithJson(w, http.StatusOK, result)
 }
 
@@ -402,7 +562,7 @@ func (c rulesetControllerImpl) DeleteRuleset(w http.ResponseWriter, r *http.Requ
 
 func validateApiType(at view.ApiType) error {
 	switch at {
-	case view.OpenAPI20Type, view.OpenAPI30Type, view.OpenAPI31Type:
+	case view.OpenAPI20Type, view.OpenAPI30Type, view.OpenAPI31Type, view.AsyncAPI2Type, view.AsyncAPI3Type, view.GraphQLType:
 		return nil
 	default:
 		return &exception.CustomError{
@@ -414,16 +574,233 @@ func validateApiType(at view.ApiType) error {
 	}
 }
 
-func validateLinter(linter view.Linter) error {
-	switch linter {
-	case view.SpectralLinter:
+func (c rulesetControllerImpl) validateLinter(linter view.Linter) error {
+	if c.linterRegistry.IsLinterSupported(linter) {
 		return nil
-	default:
-		return &exception.CustomError{
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.InvalidParameterValue,
+		Message: exception.InvalidParameterValueMsg,
+		Params:  map[string]interface{}{"params": "linter"},
+	}
+}
+
+func (c rulesetControllerImpl) DryRunRuleset(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
-			Code:    exception.InvalidParameterValue,
-			Message: exception.InvalidParameterValueMsg,
-			Params:  map[string]interface{}{"params": "linter"},
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.RulesetDryRunRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var missingParams []string
+	if req.PackageId == "" {
+		missingParams = append(missingParams, "packageId")
+	}
+	if req.Version == "" {
+		missingParams = append(missingParams, "version")
+	}
+	if len(missingParams) > 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": strings.Join(missingParams, ", ")},
+		})
+		return
+	}
+
+	ctx := secctx.MakeUserContext(r)
+	// the same users who can bind a ruleset to the package are allowed to try it
+	sufficientPrivileges, err := c.authorizationService.HasRulesetBindingManagementPermission(ctx, req.PackageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.rulesetDryRunService.DryRunRuleset(ctx, rulesetId, req)
+	if err != nil {
+		respondWithError(w, "Failed to dry run ruleset", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetControllerImpl) ListRulesetRevisions(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetReadPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.rulesetService.ListRulesetRevisions(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to list ruleset revisions", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetControllerImpl) GetRulesetDiff(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetReadPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	baseRevision := 0
+	if r.URL.Query().Get("baseRevision") != "" {
+		baseRevision, err = strconv.Atoi(r.URL.Query().Get("baseRevision"))
+		if err != nil || baseRevision < 1 {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "baseRevision", "type": "positive int"},
+			})
+			return
 		}
 	}
+
+	result, err := c.rulesetService.GetRulesetDiff(ctx, rulesetId, baseRevision)
+	if err != nil {
+		respondWithError(w, "Failed to get ruleset diff", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetControllerImpl) GetRuleCatalog(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetReadPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.ruleCatalogService.GetRuleCatalog(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to get rule catalog", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetControllerImpl) ListRelintCampaigns(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetReadPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.relintCampaignService.ListCampaigns(ctx, rulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to list re-lint campaigns", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetControllerImpl) GetRelintCampaign(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+	campaignId := getStringParam(r, "campaign_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetReadPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.relintCampaignService.GetCampaign(ctx, rulesetId, campaignId)
+	if err != nil {
+		respondWithError(w, "Failed to get re-lint campaign", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func readFileHeader(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
 }
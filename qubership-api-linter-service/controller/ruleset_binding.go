@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type RulesetBindingController interface {
+	ListRulesetBindings(w http.ResponseWriter, r *http.Request)
+	BindRuleset(w http.ResponseWriter, r *http.Request)
+	UnbindRuleset(w http.ResponseWriter, r *http.Request)
+	GetRulesetBindingHistory(w http.ResponseWriter, r *http.Request)
+}
+
+func NewRulesetBindingController(rulesetBindingService service.RulesetBindingService, authorizationService service.AuthorizationService) RulesetBindingController {
+	return &rulesetBindingControllerImpl{rulesetBindingService: rulesetBindingService, authorizationService: authorizationService}
+}
+
+type rulesetBindingControllerImpl struct {
+	rulesetBindingService service.RulesetBindingService
+	authorizationService  service.AuthorizationService
+}
+
+func (c rulesetBindingControllerImpl) ListRulesetBindings(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.rulesetBindingService.ListBindings(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to list ruleset bindings", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetBindingControllerImpl) BindRuleset(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetBindingManagementPermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	apiType := view.ApiType(getStringParam(r, "apiType"))
+	err = validateApiType(apiType)
+	if err != nil {
+		respondWithError(w, "incorrect api type", err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.BindRulesetRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	if req.RulesetId == "" {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "rulesetId"},
+		})
+		return
+	}
+
+	result, err := c.rulesetBindingService.BindRuleset(ctx, packageId, apiType, req.RulesetId)
+	if err != nil {
+		respondWithError(w, "Failed to bind ruleset", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c rulesetBindingControllerImpl) UnbindRuleset(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetBindingManagementPermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	apiType := view.ApiType(getStringParam(r, "apiType"))
+	err = validateApiType(apiType)
+	if err != nil {
+		respondWithError(w, "incorrect api type", err)
+		return
+	}
+
+	err = c.rulesetBindingService.UnbindRuleset(ctx, packageId, apiType)
+	if err != nil {
+		respondWithError(w, "Failed to unbind ruleset", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c rulesetBindingControllerImpl) GetRulesetBindingHistory(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.rulesetBindingService.GetBindingHistory(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to get ruleset binding history", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type RulesetImpactController interface {
+	StartImpactReport(w http.ResponseWriter, r *http.Request)
+	GetImpactReport(w http.ResponseWriter, r *http.Request)
+}
+
+func NewRulesetImpactController(rulesetImpactService service.RulesetImpactService, authorizationService service.AuthorizationService) RulesetImpactController {
+	return &rulesetImpactControllerImpl{rulesetImpactService: rulesetImpactService, authorizationService: authorizationService}
+}
+
+type rulesetImpactControllerImpl struct {
+	rulesetImpactService service.RulesetImpactService
+	authorizationService service.AuthorizationService
+}
+
+func (c rulesetImpactControllerImpl) StartImpactReport(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetManagementPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	var req view.RulesetImpactRequest
+	body, err := io.ReadAll(r.Body)
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	result, err := c.rulesetImpactService.StartImpactReport(ctx, rulesetId, req)
+	if err != nil {
+		respondWithError(w, "Failed to start ruleset impact report", err)
+		return
+	}
+	respondWithJson(w, http.StatusAccepted, result)
+}
+
+func (c rulesetImpactControllerImpl) GetImpactReport(w http.ResponseWriter, r *http.Request) {
+	rulesetId := getStringParam(r, "ruleset_id")
+	reportId := getStringParam(r, "report_id")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasRulesetManagementPermission(ctx)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := c.rulesetImpactService.GetImpactReport(ctx, rulesetId, reportId)
+	if err != nil {
+		respondWithError(w, "Failed to get ruleset impact report", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/Netcracker/qubership-api-linter-service/exception"
 	"github.com/Netcracker/qubership-api-linter-service/secctx"
 	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
 	log "github.com/sirupsen/logrus"
 	"net/http"
 )
@@ -65,7 +66,7 @@ func (v *validationControllerImpl) ValidateVersion(w http.ResponseWriter, r *htt
 		return
 	}
 
-	taskId, err := v.validationService.ValidateVersion(ctx, packageId, version, "")
+	taskId, err := v.validationService.ValidateVersion(ctx, packageId, version, "", view.TaskPriorityUser)
 	if err != nil {
 		respondWithError(w, "Failed to start version validation", err)
 		return
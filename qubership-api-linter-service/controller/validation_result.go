@@ -1,29 +1,43 @@
 package controller
 
 import (
+	"encoding/json"
 	"github.com/Netcracker/qubership-api-linter-service/exception"
 	"github.com/Netcracker/qubership-api-linter-service/secctx"
 	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
 	"net/http"
 )
 
 type ValidationResultController interface {
 	GetValidationSummaryForVersion(w http.ResponseWriter, r *http.Request)
 	GetValidationResultForDocument(w http.ResponseWriter, r *http.Request)
+	GetValidationDiffForVersion(w http.ResponseWriter, r *http.Request)
+	GetValidationSarifForVersion(w http.ResponseWriter, r *http.Request)
 }
 
-func NewValidationResultController(validationService service.ValidationService, authorizationService service.AuthorizationService) ValidationResultController {
+func NewValidationResultController(validationService service.ValidationService, lintDiffService service.LintDiffService,
+	sarifExportService service.SarifExportService, authorizationService service.AuthorizationService) ValidationResultController {
 	return &validationResultControllerImpl{
 		validationService:    validationService,
+		lintDiffService:      lintDiffService,
+		sarifExportService:   sarifExportService,
 		authorizationService: authorizationService,
 	}
 }
 
 type validationResultControllerImpl struct {
 	validationService    service.ValidationService
+	lintDiffService      service.LintDiffService
+	sarifExportService   service.SarifExportService
 	authorizationService service.AuthorizationService
 }
 
+const (
+	resultFormatJson  = "json"
+	resultFormatSarif = "sarif"
+)
+
 func (v validationResultControllerImpl) GetValidationSummaryForVersion(w http.ResponseWriter, r *http.Request) {
 	packageId := getStringParam(r, "packageId")
 
@@ -112,6 +126,36 @@ func (v validationResultControllerImpl) GetValidationResultForDocument(w http.Re
 		return
 	}
 
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", resultFormatJson:
+	case resultFormatSarif:
+		sarifLog, err := v.sarifExportService.GetDocumentSarif(ctx, packageId, versionName, slug)
+		if err != nil {
+			respondWithError(w, "Failed to get SARIF validation result for document", err)
+			return
+		}
+		if sarifLog == nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.LintResultNotFound,
+				Message: exception.LintResultNotFoundMsg,
+				Params:  map[string]interface{}{"packageId": packageId, "version": versionName},
+			})
+			return
+		}
+		respondWithSarif(w, sarifLog)
+		return
+	default:
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "format", "value": format},
+		})
+		return
+	}
+
 	result, err := v.validationService.GetValidationResult(secctx.MakeUserContext(r), packageId, versionName, slug)
 	if err != nil {
 		respondWithError(w, "Failed to get validation result for document", err)
@@ -127,3 +171,113 @@ func (v validationResultControllerImpl) GetValidationResultForDocument(w http.Re
 	}
 	respondWithJson(w, http.StatusOK, result)
 }
+
+func (v validationResultControllerImpl) GetValidationDiffForVersion(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := v.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	versionName, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	previousVersion := r.URL.Query().Get("previousVersion")
+	previousVersionPackageId := r.URL.Query().Get("previousVersionPackageId")
+
+	result, err := v.lintDiffService.GetVersionDiff(ctx, packageId, versionName, previousVersionPackageId, previousVersion)
+	if err != nil {
+		respondWithError(w, "Failed to get validation diff for version", err)
+		return
+	}
+	if result.PreviousPackageId != packageId {
+		// the previous version may belong to another package
+		sufficientPrivileges, err = v.authorizationService.HasReadPackagePermission(ctx, result.PreviousPackageId)
+		if err != nil {
+			respondWithError(w, "Failed to check permissions", err)
+			return
+		}
+		if !sufficientPrivileges {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusForbidden,
+				Code:    exception.InsufficientPrivileges,
+				Message: exception.InsufficientPrivilegesMsg,
+			})
+			return
+		}
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (v validationResultControllerImpl) GetValidationSarifForVersion(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := v.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	versionName, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	result, err := v.sarifExportService.GetVersionSarif(ctx, packageId, versionName)
+	if err != nil {
+		respondWithError(w, "Failed to get SARIF validation result for version", err)
+		return
+	}
+	if result == nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.LintResultNotFound,
+			Message: exception.LintResultNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "version": versionName},
+		})
+		return
+	}
+	respondWithSarif(w, result)
+}
+
+func respondWithSarif(w http.ResponseWriter, sarifLog *view.SarifLog) {
+	response, _ := json.Marshal(sarifLog)
+	w.Header().Set("Content-Type", view.SarifMediaType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
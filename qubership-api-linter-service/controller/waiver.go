@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/service"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type WaiverController interface {
+	CreateWaiver(w http.ResponseWriter, r *http.Request)
+	ListWaivers(w http.ResponseWriter, r *http.Request)
+	RevokeWaiver(w http.ResponseWriter, r *http.Request)
+}
+
+func NewWaiverController(waiverService service.WaiverService, authorizationService service.AuthorizationService) WaiverController {
+	return &waiverControllerImpl{waiverService: waiverService, authorizationService: authorizationService}
+}
+
+type waiverControllerImpl struct {
+	waiverService        service.WaiverService
+	authorizationService service.AuthorizationService
+}
+
+func (c waiverControllerImpl) CreateWaiver(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasPublishPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.CreateIssueWaiverRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	result, err := c.waiverService.CreateWaiver(ctx, packageId, req)
+	if err != nil {
+		respondWithError(w, "Failed to create issue waiver", err)
+		return
+	}
+	respondWithJson(w, http.StatusCreated, result)
+}
+
+func (c waiverControllerImpl) ListWaivers(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasReadPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	includeInactive := false
+	if r.URL.Query().Get("includeInactive") != "" {
+		includeInactive, err = strconv.ParseBool(r.URL.Query().Get("includeInactive"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "includeInactive", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := c.waiverService.ListWaivers(ctx, packageId, !includeInactive)
+	if err != nil {
+		respondWithError(w, "Failed to list issue waivers", err)
+		return
+	}
+	respondWithJson(w, http.StatusOK, result)
+}
+
+func (c waiverControllerImpl) RevokeWaiver(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+
+	ctx := secctx.MakeUserContext(r)
+	sufficientPrivileges, err := c.authorizationService.HasPublishPackagePermission(ctx, packageId)
+	if err != nil {
+		respondWithError(w, "Failed to check permissions", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	waiverId := getStringParam(r, "waiverId")
+	err = c.waiverService.RevokeWaiver(ctx, packageId, waiverId)
+	if err != nil {
+		respondWithError(w, "Failed to revoke issue waiver", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -15,6 +15,7 @@ type LintedDocument struct {
 	DataHash          string                    `pg:"data_hash,type:varchar"`
 	LintStatus        view.LintedDocumentStatus `pg:"lint_status,type:varchar,notnull"`
 	LintDetails       string                    `pg:"lint_details,type:varchar"`
+	ApihubChecksum    string                    `pg:"apihub_checksum,type:varchar"`
 }
 
 // TODO: choose linted vs validated term!
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+// DraftLintReport is the ephemeral lint result for not published documents, it's removed after expiration
+type DraftLintReport struct {
+	tableName struct{} `pg:"draft_lint_report"`
+
+	Id        string                           `pg:"id,pk,type:varchar"`
+	PackageId string                           `pg:"package_id,type:varchar,notnull"`
+	CreatedBy string                           `pg:"created_by,type:varchar,notnull"`
+	CreatedAt time.Time                        `pg:"created_at,type:timestamp without time zone,notnull"`
+	ExpiresAt time.Time                        `pg:"expires_at,type:timestamp without time zone,notnull"`
+	Summary   view.ValidationSummaryForVersion `pg:"summary,type:jsonb,notnull"`
+	Documents []view.DocumentResult            `pg:"documents,type:jsonb,notnull"`
+}
+
+func MakeDraftValidationSummaryView(ent DraftLintReport) view.DraftValidationSummary {
+	return view.DraftValidationSummary{
+		DraftId:                     ent.Id,
+		PackageId:                   ent.PackageId,
+		CreatedAt:                   ent.CreatedAt,
+		ExpiresAt:                   ent.ExpiresAt,
+		ValidationSummaryForVersion: ent.Summary,
+	}
+}
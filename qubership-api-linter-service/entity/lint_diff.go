@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type VersionLintDiff struct {
+	tableName struct{} `pg:"version_lint_diff"`
+
+	PackageId         string                  `pg:"package_id,pk,type:varchar"`
+	Version           string                  `pg:"version,pk,type:varchar"`
+	Revision          int                     `pg:"revision,pk,type:integer"`
+	PreviousPackageId string                  `pg:"previous_package_id,type:varchar,notnull"`
+	PreviousVersion   string                  `pg:"previous_version,type:varchar,notnull"`
+	PreviousRevision  int                     `pg:"previous_revision,type:integer,notnull"`
+	LintedAt          time.Time               `pg:"linted_at,type:timestamp without time zone,notnull"`
+	PreviousLintedAt  time.Time               `pg:"previous_linted_at,type:timestamp without time zone,notnull"`
+	CreatedAt         time.Time               `pg:"created_at,type:timestamp without time zone,notnull"`
+	Summary           view.LintDiffSummary    `pg:"summary,type:jsonb,notnull"`
+	Documents         []view.DocumentLintDiff `pg:"documents,type:jsonb,notnull"`
+}
+
+func MakeVersionLintDiffView(ent VersionLintDiff) view.VersionLintDiff {
+	return view.VersionLintDiff{
+		PackageId:         ent.PackageId,
+		Version:           fmt.Sprintf("%s@%d", ent.Version, ent.Revision),
+		PreviousPackageId: ent.PreviousPackageId,
+		PreviousVersion:   fmt.Sprintf("%s@%d", ent.PreviousVersion, ent.PreviousRevision),
+		Summary:           ent.Summary,
+		Documents:         ent.Documents,
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type QualityGatePolicy struct {
+	MaxErrors     *int      `pg:"max_errors,type:integer"`
+	MaxWarnings   *int      `pg:"max_warnings,type:integer"`
+	NoNewErrors   bool      `pg:"no_new_errors,type:boolean,use_zero,notnull"`
+	NoNewWarnings bool      `pg:"no_new_warnings,type:boolean,use_zero,notnull"`
+	UpdatedAt     time.Time `pg:"updated_at,type:timestamp without time zone,notnull"`
+	UpdatedBy     string    `pg:"updated_by,type:varchar,notnull"`
+}
+
+type RulesetQualityGate struct {
+	tableName struct{} `pg:"ruleset_quality_gate"`
+
+	RulesetId string `pg:"ruleset_id,pk,type:varchar"`
+	QualityGatePolicy
+}
+
+type PackageQualityGate struct {
+	tableName struct{} `pg:"package_quality_gate"`
+
+	PackageId string `pg:"package_id,pk,type:varchar"`
+	QualityGatePolicy
+}
+
+func MakeQualityGatePolicyEntity(policy view.QualityGatePolicy, updatedAt time.Time, updatedBy string) QualityGatePolicy {
+	return QualityGatePolicy{
+		MaxErrors:     policy.MaxErrors,
+		MaxWarnings:   policy.MaxWarnings,
+		NoNewErrors:   policy.NoNewErrors,
+		NoNewWarnings: policy.NoNewWarnings,
+		UpdatedAt:     updatedAt,
+		UpdatedBy:     updatedBy,
+	}
+}
+
+func MakeQualityGateView(ent QualityGatePolicy, scope view.QualityGateScope, scopeId string) view.QualityGate {
+	return view.QualityGate{
+		Scope:   scope,
+		ScopeId: scopeId,
+		Policy: view.QualityGatePolicy{
+			MaxErrors:     ent.MaxErrors,
+			MaxWarnings:   ent.MaxWarnings,
+			NoNewErrors:   ent.NoNewErrors,
+			NoNewWarnings: ent.NoNewWarnings,
+		},
+		UpdatedAt: ent.UpdatedAt,
+		UpdatedBy: ent.UpdatedBy,
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type RelintCampaign struct {
+	tableName struct{} `pg:"relint_campaign"`
+
+	Id                 string                   `pg:"id,pk,type:varchar"`
+	RulesetId          string                   `pg:"ruleset_id,type:varchar,notnull"`
+	Status             view.LintedVersionStatus `pg:"status,type:varchar,notnull"`
+	Details            string                   `pg:"details,type:varchar"`
+	VersionsPerPackage int                      `pg:"versions_per_package,type:integer,notnull"`
+	CreatedAt          time.Time                `pg:"created_at,type:timestamp without time zone,notnull"`
+	CreatedBy          string                   `pg:"created_by,type:varchar,notnull"`
+	UpdatedAt          time.Time                `pg:"updated_at,type:timestamp without time zone,notnull"`
+	ExecutorId         string                   `pg:"executor_id,type:varchar"`
+	LastActive         *time.Time               `pg:"last_active,type:timestamp without time zone"`
+}
+
+type RelintCampaignVersion struct {
+	tableName struct{} `pg:"relint_campaign_version"`
+
+	CampaignId string                   `pg:"campaign_id,pk,type:varchar"`
+	PackageId  string                   `pg:"package_id,pk,type:varchar"`
+	Version    string                   `pg:"version,pk,type:varchar"`
+	Revision   int                      `pg:"revision,type:integer,notnull,use_zero"`
+	TaskId     string                   `pg:"task_id,type:varchar"`
+	Status     view.RelintVersionStatus `pg:"status,type:varchar,notnull"`
+	Details    string                   `pg:"details,type:varchar"`
+}
+
+func MakeRelintCampaignView(ent RelintCampaign, progress map[view.RelintVersionStatus]int) view.RelintCampaign {
+	result := view.RelintCampaign{
+		Id:                 ent.Id,
+		RulesetId:          ent.RulesetId,
+		Status:             ent.Status,
+		Details:            ent.Details,
+		VersionsPerPackage: ent.VersionsPerPackage,
+		CreatedAt:          ent.CreatedAt,
+		CreatedBy:          ent.CreatedBy,
+		UpdatedAt:          ent.UpdatedAt,
+		Progress: view.RelintProgress{
+			Pending:   progress[view.RelintVersionPending],
+			Enqueued:  progress[view.RelintVersionEnqueued],
+			Succeeded: progress[view.RelintVersionSuccess],
+			Failed:    progress[view.RelintVersionError],
+			Skipped:   progress[view.RelintVersionSkipped],
+		},
+	}
+	for _, count := range progress {
+		result.Progress.Total += count
+	}
+	return result
+}
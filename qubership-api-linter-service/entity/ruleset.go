@@ -18,6 +18,8 @@ type Ruleset struct {
 	FileName      string             `pg:"file_name,type:varchar"`
 	CanBeDeleted  bool               `pg:"can_be_deleted,type:bool"`
 	LastActivated *time.Time         `pg:"last_activated,type:timestamp without time zone"`
+	Parents       []string           `pg:"parents,array,type:varchar[]"`
+	Revision      int                `pg:"revision,type:integer,notnull"`
 }
 
 type RulesetWithData struct {
@@ -27,14 +29,40 @@ type RulesetWithData struct {
 	Data []byte `pg:"data,type:bytea,notnull"`
 }
 
+type RulesetFile struct {
+	tableName struct{} `pg:"ruleset_file"`
+
+	RulesetId string `pg:"ruleset_id,pk,type:varchar"`
+	Path      string `pg:"path,pk,type:varchar"`
+	Data      []byte `pg:"data,type:bytea,notnull"`
+}
+
 type RulesetActivationHistory struct {
 	tableName struct{} `pg:"ruleset_activation_history"`
 
-	RulesetId     string    `pg:"ruleset_id,type:varchar,notnull"`
-	ActivatedAt   time.Time `pg:"activated_at,type:timestamp without time zone"`
-	ActivatedBy   string    `pg:"activated_by,type:varchar"`
-	DeactivatedAt time.Time `pg:"deactivated_at,type:timestamp without time zone"`
-	DeactivatedBy string    `pg:"deactivated_by,type:varchar"`
+	RulesetId      string              `pg:"ruleset_id,type:varchar,notnull"`
+	ActivatedAt    time.Time           `pg:"activated_at,type:timestamp without time zone"`
+	ActivatedBy    string              `pg:"activated_by,type:varchar"`
+	DeactivatedAt  time.Time           `pg:"deactivated_at,type:timestamp without time zone"`
+	DeactivatedBy  string              `pg:"deactivated_by,type:varchar"`
+	ActivationType view.ActivationType `pg:"activation_type,type:varchar,notnull"`
+	ScheduledBy    string              `pg:"scheduled_by,type:varchar"`
+}
+
+type RulesetActivationSchedule struct {
+	tableName struct{} `pg:"ruleset_activation_schedule"`
+
+	Id          string                        `pg:"id,pk,type:varchar"`
+	RulesetId   string                        `pg:"ruleset_id,type:varchar,notnull"`
+	ActivateAt  time.Time                     `pg:"activate_at,type:timestamp without time zone,notnull"`
+	Status      view.ActivationScheduleStatus `pg:"status,type:varchar,notnull"`
+	Details     string                        `pg:"details,type:varchar"`
+	CreatedAt   time.Time                     `pg:"created_at,type:timestamp without time zone,notnull"`
+	CreatedBy   string                        `pg:"created_by,type:varchar,notnull"`
+	CancelledAt *time.Time                    `pg:"cancelled_at,type:timestamp without time zone"`
+	CancelledBy string                        `pg:"cancelled_by,type:varchar"`
+	ExecutedAt  *time.Time                    `pg:"executed_at,type:timestamp without time zone"`
+	Relint      bool                          `pg:"relint,type:boolean,notnull,use_zero"`
 }
 
 func MakeRulesetView(ent Ruleset) view.Ruleset {
@@ -47,6 +75,9 @@ func MakeRulesetView(ent Ruleset) view.Ruleset {
 		ApiType:      ent.ApiType,
 		CreatedAt:    ent.CreatedAt,
 		CanBeDeleted: ent.CanBeDeleted,
+		Parents:      ent.Parents,
+		Lineage:      ent.Name,
+		Revision:     ent.Revision,
 	}
 }
 
@@ -56,7 +87,27 @@ func MakeActivationRecordView(ent RulesetActivationHistory) view.ActivationRecor
 		to = &ent.DeactivatedAt
 	}
 	return view.ActivationRecord{
-		ActiveFrom: ent.ActivatedAt,
-		ActiveTo:   to,
+		ActiveFrom:     ent.ActivatedAt,
+		ActiveTo:       to,
+		ActivatedBy:    ent.ActivatedBy,
+		DeactivatedBy:  ent.DeactivatedBy,
+		ActivationType: ent.ActivationType,
+		ScheduledBy:    ent.ScheduledBy,
+	}
+}
+
+func MakeActivationScheduleView(ent RulesetActivationSchedule) view.ActivationSchedule {
+	return view.ActivationSchedule{
+		Id:          ent.Id,
+		RulesetId:   ent.RulesetId,
+		ActivateAt:  ent.ActivateAt,
+		Status:      ent.Status,
+		Details:     ent.Details,
+		CreatedAt:   ent.CreatedAt,
+		CreatedBy:   ent.CreatedBy,
+		CancelledAt: ent.CancelledAt,
+		CancelledBy: ent.CancelledBy,
+		ExecutedAt:  ent.ExecutedAt,
+		Relint:      ent.Relint,
 	}
 }
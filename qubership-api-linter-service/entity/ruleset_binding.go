@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type RulesetBinding struct {
+	tableName struct{} `pg:"ruleset_binding"`
+
+	PackageId string       `pg:"package_id,pk,type:varchar"`
+	ApiType   view.ApiType `pg:"api_type,pk,type:varchar"`
+	RulesetId string       `pg:"ruleset_id,type:varchar,notnull"`
+	CreatedAt time.Time    `pg:"created_at,type:timestamp without time zone,notnull"`
+	CreatedBy string       `pg:"created_by,type:varchar,notnull"`
+}
+
+type RulesetBindingHistory struct {
+	tableName struct{} `pg:"ruleset_binding_history"`
+
+	PackageId     string       `pg:"package_id,type:varchar,notnull"`
+	ApiType       view.ApiType `pg:"api_type,type:varchar,notnull"`
+	RulesetId     string       `pg:"ruleset_id,type:varchar,notnull"`
+	ActivatedAt   time.Time    `pg:"activated_at,type:timestamp without time zone"`
+	ActivatedBy   string       `pg:"activated_by,type:varchar"`
+	DeactivatedAt *time.Time   `pg:"deactivated_at,type:timestamp without time zone"`
+	DeactivatedBy string       `pg:"deactivated_by,type:varchar"`
+}
+
+func MakeRulesetBindingView(ent RulesetBinding, ruleset Ruleset) view.RulesetBinding {
+	return view.RulesetBinding{
+		PackageId: ent.PackageId,
+		ApiType:   ent.ApiType,
+		Ruleset:   MakeRulesetView(ruleset),
+		CreatedAt: ent.CreatedAt,
+		CreatedBy: ent.CreatedBy,
+	}
+}
+
+func MakeRulesetBindingHistoryView(ent RulesetBindingHistory) view.RulesetBindingHistoryRecord {
+	return view.RulesetBindingHistoryRecord{
+		ApiType:    ent.ApiType,
+		RulesetId:  ent.RulesetId,
+		ActiveFrom: ent.ActivatedAt,
+		ActiveTo:   ent.DeactivatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type RulesetImpactReport struct {
+	tableName struct{} `pg:"ruleset_impact_report"`
+
+	Id                string                      `pg:"id,pk,type:varchar"`
+	RulesetId         string                      `pg:"ruleset_id,type:varchar,notnull"`
+	Status            view.LintedVersionStatus    `pg:"status,type:varchar,notnull"`
+	Details           string                      `pg:"details,type:varchar"`
+	CreatedAt         time.Time                   `pg:"created_at,type:timestamp without time zone,notnull"`
+	CreatedBy         string                      `pg:"created_by,type:varchar,notnull"`
+	UpdatedAt         time.Time                   `pg:"updated_at,type:timestamp without time zone,notnull"`
+	PackagesTotal     int                         `pg:"packages_total,type:integer,use_zero"`
+	PackagesProcessed int                         `pg:"packages_processed,type:integer,use_zero"`
+	Packages          []view.RulesetImpactPackage `pg:"packages,type:jsonb,notnull"`
+}
+
+func MakeRulesetImpactReportView(ent RulesetImpactReport) view.RulesetImpactReport {
+	result := view.RulesetImpactReport{
+		Id:                ent.Id,
+		RulesetId:         ent.RulesetId,
+		Status:            ent.Status,
+		Details:           ent.Details,
+		CreatedAt:         ent.CreatedAt,
+		CreatedBy:         ent.CreatedBy,
+		UpdatedAt:         ent.UpdatedAt,
+		PackagesTotal:     ent.PackagesTotal,
+		PackagesProcessed: ent.PackagesProcessed,
+		Packages:          ent.Packages,
+	}
+	if result.Packages == nil {
+		result.Packages = make([]view.RulesetImpactPackage, 0)
+	}
+	if ent.Status == view.VersionStatusSuccess {
+		total := view.RulesetImpactSummary{}
+		for _, pkg := range ent.Packages {
+			total.Current = addIssuesSummary(total.Current, pkg.Current)
+			total.Candidate = addIssuesSummary(total.Candidate, pkg.Candidate)
+			total.Delta = addIssuesSummary(total.Delta, pkg.Delta)
+		}
+		result.Total = &total
+	}
+	return result
+}
+
+func addIssuesSummary(a view.IssuesSummary, b view.IssuesSummary) view.IssuesSummary {
+	return view.IssuesSummary{
+		Error:   a.Error + b.Error,
+		Warning: a.Warning + b.Warning,
+		Info:    a.Info + b.Info,
+		Hint:    a.Hint + b.Hint,
+	}
+}
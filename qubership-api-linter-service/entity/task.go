@@ -44,4 +44,6 @@ type DocumentLintTask struct {
 	RestartCount      int             `pg:"restart_count,type:integer,notnull,use_zero"`
 	Priority          int             `pg:"priority, type:integer use_zero"`
 	LintTimeMs        int64           `pg:"lint_time_ms,type:integer,notnull,use_zero"`
+	CacheHit          bool            `pg:"cache_hit,type:boolean,notnull,use_zero"`
+	ApihubChecksum    string          `pg:"apihub_checksum,type:varchar"`
 }
@@ -14,4 +14,7 @@ type LintedVersion struct {
 	LintStatus  view.LintedVersionStatus `pg:"lint_status,type:varchar,notnull"`
 	LintDetails string                   `pg:"lint_details,type:varchar"`
 	LintedAt    time.Time                `pg:"linted_at,type:timestamp without time zone,notnull"`
+
+	QualityGateStatus  view.QualityGateStatus `pg:"quality_gate_status,type:varchar"`
+	QualityGateReasons []string               `pg:"quality_gate_reasons,type:jsonb"`
 }
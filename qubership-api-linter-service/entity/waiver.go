@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+type IssueWaiver struct {
+	tableName struct{} `pg:"issue_waiver"`
+
+	Id            string     `pg:"id,pk,type:varchar"`
+	PackageId     string     `pg:"package_id,type:varchar,notnull"`
+	VersionFrom   string     `pg:"version_from,type:varchar"`
+	VersionTo     string     `pg:"version_to,type:varchar"`
+	Slug          string     `pg:"slug,type:varchar"`
+	RuleCode      string     `pg:"rule_code,type:varchar"`
+	Path          string     `pg:"path,type:varchar"`
+	Justification string     `pg:"justification,type:varchar,notnull"`
+	ExpiresAt     time.Time  `pg:"expires_at,type:timestamp without time zone,notnull"`
+	CreatedAt     time.Time  `pg:"created_at,type:timestamp without time zone,notnull"`
+	CreatedBy     string     `pg:"created_by,type:varchar,notnull"`
+	RevokedAt     *time.Time `pg:"revoked_at,type:timestamp without time zone"`
+	RevokedBy     string     `pg:"revoked_by,type:varchar"`
+}
+
+func MakeIssueWaiverView(ent IssueWaiver, now time.Time) view.IssueWaiver {
+	return view.IssueWaiver{
+		Id:            ent.Id,
+		PackageId:     ent.PackageId,
+		VersionFrom:   ent.VersionFrom,
+		VersionTo:     ent.VersionTo,
+		Slug:          ent.Slug,
+		RuleCode:      ent.RuleCode,
+		Path:          ent.Path,
+		Justification: ent.Justification,
+		ExpiresAt:     ent.ExpiresAt,
+		CreatedAt:     ent.CreatedAt,
+		CreatedBy:     ent.CreatedBy,
+		RevokedAt:     ent.RevokedAt,
+		RevokedBy:     ent.RevokedBy,
+		Active:        ent.RevokedAt == nil && ent.ExpiresAt.After(now),
+	}
+}
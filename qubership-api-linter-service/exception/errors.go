@@ -76,8 +76,68 @@ const RulesetCanNotBeDeletedMsg = "Ruleset with $id can not be deleted because i
 const RulesetNameDuplicated = "2001"
 const RulesetNameDuplicatedMsg = "Ruleset name $name is not unique for API type $type"
 
+const LinterNotSupportedForApiType = "2002"
+const LinterNotSupportedForApiTypeMsg = "Linter $linter is not supported for API type $type"
+
+const RulesetIsExtended = "2003"
+const RulesetIsExtendedMsg = "Ruleset $id can not be deleted because it's extended by rulesets: $children"
+
+const RulesetParentNotFound = "2004"
+const RulesetParentNotFoundMsg = "Parent ruleset $parent is not found for API type $type"
+
+const RulesetParentLinterMismatch = "2005"
+const RulesetParentLinterMismatchMsg = "Parent ruleset $parent is intended for linter $parentLinter, but the ruleset is for linter $linter"
+
+const RulesetCompositionNotSupported = "2006"
+const RulesetCompositionNotSupportedMsg = "Ruleset composition is not supported for $reason"
+
+const RulesetDependencyCycle = "2007"
+const RulesetDependencyCycleMsg = "Ruleset dependency cycle detected: $cycle"
+
+const IncorrectRulesetFile = "2008"
+const IncorrectRulesetFileMsg = "Ruleset file $name is not allowed: $reason"
+
+const IncorrectRulesetBundle = "2009"
+const IncorrectRulesetBundleMsg = "Ruleset bundle $name is incorrect: $reason"
+
+const RulesetValidationFailed = "2010"
+const RulesetValidationFailedMsg = "Ruleset $name is not valid for linter $linter: $problems"
+
+const RulesetActivationAlreadyScheduled = "2011"
+const RulesetActivationAlreadyScheduledMsg = "Activation of ruleset $id is already scheduled at $activateAt"
+
+const RulesetRollbackNotPossible = "2012"
+const RulesetRollbackNotPossibleMsg = "Activation of ruleset $id can not be rolled back: $reason"
+
+const RulesetDiffNotSupported = "2013"
+const RulesetDiffNotSupportedMsg = "Rules of ruleset $id can not be compared: $reason"
+
+const RulesetRevisionNotFound = "2014"
+const RulesetRevisionNotFoundMsg = "Revision $revision of ruleset $name is not found for API type $type"
+
+const MultipleActiveRulesets = "2015"
+const MultipleActiveRulesetsMsg = "Several rulesets are active for API type $type: $ids"
+
 const LintResultNotFound = "2100"
 const LintResultNotFoundMsg = "Validation result not found for packageId $packageId and version $version"
 
+const PreviousVersionNotFound = "2101"
+const PreviousVersionNotFoundMsg = "Version $version of package $packageId has no previous version"
+
 const LintNotSupported = "2200"
 const LintNotSupportedMsg = "Validation is not supported for kind=$kind (id=%id), only for kind='package'"
+
+const MutuallyExclusiveParams = "16"
+const MutuallyExclusiveParamsMsg = "Parameters $params are mutually exclusive"
+
+const UnknownDocumentApiType = "2300"
+const UnknownDocumentApiTypeMsg = "Unable to detect API type of document $name"
+
+const RulesetApiTypeMismatch = "2301"
+const RulesetApiTypeMismatchMsg = "Ruleset $id is intended for API type $rulesetType, but API type $type is requested"
+
+const DocumentLintFailed = "2302"
+const DocumentLintFailedMsg = "Failed to lint document $name: $error"
+
+const RulesetSuperseded = "2303"
+const RulesetSupersededMsg = "Ruleset $id is not active: revision $revision of $name is superseded by revision $latestRevision"
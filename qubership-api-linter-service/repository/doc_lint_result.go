@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Netcracker/qubership-api-linter-service/db"
 	"github.com/Netcracker/qubership-api-linter-service/entity"
@@ -10,8 +11,11 @@ import (
 )
 
 type DocResultRepository interface {
-	LintResultExists(ctx context.Context, dataHash string) (bool, error)
-	SaveLintResult(ctx context.Context, docLintTaskId string, status view.LintedDocumentStatus, details string, lintTimeMs int64, version entity.LintedVersion, document entity.LintedDocument, result *entity.LintFileResult, executorId string) error
+	LintResultExists(ctx context.Context, dataHash string, rulesetId string, linterVersion string) (bool, error)
+	// FindLintResultHashByChecksum returns data hash of the existing lint result for the document with the APIHUB checksum or empty string if not found
+	FindLintResultHashByChecksum(ctx context.Context, apihubChecksum string, rulesetId string, linterVersion string) (string, error)
+	// SaveLintResult stores the result of the doc task, result could be nil if the task failed or existing result was reused (cacheHit)
+	SaveLintResult(ctx context.Context, docLintTaskId string, status view.LintedDocumentStatus, details string, lintTimeMs int64, cacheHit bool, version entity.LintedVersion, document entity.LintedDocument, result *entity.LintFileResult, executorId string) error
 }
 
 func NewDocResultRepository(cp db.ConnectionProvider) DocResultRepository {
@@ -22,12 +26,33 @@ type docResultRepositoryImpl struct {
 	cp db.ConnectionProvider
 }
 
-func (d docResultRepositoryImpl) LintResultExists(ctx context.Context, dataHash string) (bool, error) {
-	//TODO implement me
-	panic("implement me")
+func (d docResultRepositoryImpl) LintResultExists(ctx context.Context, dataHash string, rulesetId string, linterVersion string) (bool, error) {
+	return d.cp.GetConnection().ModelContext(ctx, (*entity.LintFileResultSummary)(nil)).
+		Where("data_hash = ?", dataHash).
+		Where("ruleset_id = ?", rulesetId).
+		Where("linter_version = ?", linterVersion).
+		Exists()
 }
 
-func (d docResultRepositoryImpl) SaveLintResult(ctx context.Context, docLintTaskId string, status view.LintedDocumentStatus, details string, lintTimeMs int64,
+func (d docResultRepositoryImpl) FindLintResultHashByChecksum(ctx context.Context, apihubChecksum string, rulesetId string, linterVersion string) (string, error) {
+	var dataHash string
+	_, err := d.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&dataHash),
+		`select ld.data_hash from linted_document ld
+			inner join lint_file_result r on r.data_hash = ld.data_hash
+		where ld.apihub_checksum = ?
+		  and r.ruleset_id = ?
+		  and r.linter_version = ?
+		limit 1`, apihubChecksum, rulesetId, linterVersion)
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return dataHash, nil
+}
+
+func (d docResultRepositoryImpl) SaveLintResult(ctx context.Context, docLintTaskId string, status view.LintedDocumentStatus, details string, lintTimeMs int64, cacheHit bool,
 	version entity.LintedVersion, document entity.LintedDocument, result *entity.LintFileResult, executorId string) error {
 	return d.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
 
@@ -37,6 +62,7 @@ func (d docResultRepositoryImpl) SaveLintResult(ctx context.Context, docLintTask
 			Set("details = ?", details).
 			Set("last_active = now()").
 			Set("lint_time_ms = ?", lintTimeMs).
+			Set("cache_hit = ?", cacheHit).
 			Where("id = ?", docLintTaskId).
 			Where("executor_id = ?", executorId).
 			Update()
@@ -68,6 +94,7 @@ func (d docResultRepositoryImpl) SaveLintResult(ctx context.Context, docLintTask
 			Set("data_hash = EXCLUDED.data_hash").
 			Set("lint_status = EXCLUDED.lint_status").
 			Set("lint_details = EXCLUDED.lint_details").
+			Set("apihub_checksum = EXCLUDED.apihub_checksum").
 			Insert()
 		if err != nil {
 			return err
@@ -82,6 +109,6 @@ func (d docResultRepositoryImpl) SaveLintResult(ctx context.Context, docLintTask
 				return err
 			}
 		}
-		return nil
+		return notify(ctx, tx, DocTaskFinishedChannel, docLintTaskId)
 	})
 }
@@ -87,6 +87,9 @@ func (d docLintTaskRepositoryImpl) SaveDocTasksAndUpdVer(ctx context.Context, en
 		if err != nil {
 			return err
 		}
+		if !allFailed {
+			return notify(ctx, tx, DocTasksCreatedChannel, versionTaskId)
+		}
 		return nil
 	})
 	return err
@@ -94,9 +97,16 @@ func (d docLintTaskRepositoryImpl) SaveDocTasksAndUpdVer(ctx context.Context, en
 
 const buildKeepaliveTimeoutSec = 30
 
+// waiting task gains one priority point per this interval, so low priority tasks are not starving
+const taskPriorityAgingSec = 60
+
+// effective priority is priority + waiting time / aging interval. The current time is the same for all the tasks,
+// so ordering by priority - created_at / aging interval gives the same result and doesn't depend on the time zone.
+var taskPriorityOrder = fmt.Sprintf("(b.priority - extract(epoch from b.created_at) / %d) DESC, b.created_at ASC", taskPriorityAgingSec)
+
 var queryItemToBuild = fmt.Sprintf("select * from document_lint_task b where "+
 	"(b.status='%s' or (b.status='%s' and b.last_active < (now() - interval '%d seconds'))) "+
-	"order by b.created_at ASC limit 1 for no key update skip locked", view.TaskStatusNotStarted, view.TaskStatusProcessing, buildKeepaliveTimeoutSec)
+	"order by "+taskPriorityOrder+" limit 1 for no key update skip locked", view.TaskStatusNotStarted, view.TaskStatusProcessing, buildKeepaliveTimeoutSec)
 
 func (d docLintTaskRepositoryImpl) FindFreeDocTask(ctx context.Context, executorId string) (*entity.DocumentLintTask, error) {
 	var result *entity.DocumentLintTask
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type DraftLintReportRepository interface {
+	SaveDraftReport(ctx context.Context, ent entity.DraftLintReport) error
+	// GetDraftReport returns nil if the report doesn't exist or expired
+	GetDraftReport(ctx context.Context, id string) (*entity.DraftLintReport, error)
+	DeleteExpiredDraftReports(ctx context.Context) (int, error)
+}
+
+func NewDraftLintReportRepository(cp db.ConnectionProvider) DraftLintReportRepository {
+	return &draftLintReportRepositoryImpl{cp: cp}
+}
+
+type draftLintReportRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (d draftLintReportRepositoryImpl) SaveDraftReport(ctx context.Context, ent entity.DraftLintReport) error {
+	_, err := d.cp.GetConnection().ModelContext(ctx, &ent).Insert()
+	return err
+}
+
+func (d draftLintReportRepositoryImpl) GetDraftReport(ctx context.Context, id string) (*entity.DraftLintReport, error) {
+	var ent entity.DraftLintReport
+	err := d.cp.GetConnection().ModelContext(ctx, &ent).
+		Where("id = ?", id).
+		Where("expires_at > ?", time.Now()).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (d draftLintReportRepositoryImpl) DeleteExpiredDraftReports(ctx context.Context) (int, error) {
+	res, err := d.cp.GetConnection().ModelContext(ctx, (*entity.DraftLintReport)(nil)).
+		Where("expires_at <= ?", time.Now()).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
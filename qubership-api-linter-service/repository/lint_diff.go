@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type LintDiffRepository interface {
+	// SaveVersionDiff replaces the stored diff of the version
+	SaveVersionDiff(ctx context.Context, ent entity.VersionLintDiff) error
+	GetVersionDiff(ctx context.Context, packageId, version string, revision int) (*entity.VersionLintDiff, error)
+}
+
+func NewLintDiffRepository(cp db.ConnectionProvider) LintDiffRepository {
+	return &lintDiffRepositoryImpl{cp: cp}
+}
+
+type lintDiffRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (l lintDiffRepositoryImpl) SaveVersionDiff(ctx context.Context, ent entity.VersionLintDiff) error {
+	_, err := l.cp.GetConnection().ModelContext(ctx, &ent).
+		OnConflict("(package_id, version, revision) do update").
+		Set("previous_package_id = EXCLUDED.previous_package_id").
+		Set("previous_version = EXCLUDED.previous_version").
+		Set("previous_revision = EXCLUDED.previous_revision").
+		Set("linted_at = EXCLUDED.linted_at").
+		Set("previous_linted_at = EXCLUDED.previous_linted_at").
+		Set("created_at = EXCLUDED.created_at").
+		Set("summary = EXCLUDED.summary").
+		Set("documents = EXCLUDED.documents").
+		Insert()
+	return err
+}
+
+func (l lintDiffRepositoryImpl) GetVersionDiff(ctx context.Context, packageId, version string, revision int) (*entity.VersionLintDiff, error) {
+	var ent entity.VersionLintDiff
+	err := l.cp.GetConnection().ModelContext(ctx, &ent).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		Where("revision = ?", revision).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ent, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type QualityGateRepository interface {
+	GetRulesetQualityGate(ctx context.Context, rulesetId string) (*entity.RulesetQualityGate, error)
+	GetRulesetQualityGates(ctx context.Context, rulesetIds []string) ([]entity.RulesetQualityGate, error)
+	SaveRulesetQualityGate(ctx context.Context, ent entity.RulesetQualityGate) error
+	// DeleteRulesetQualityGate returns false if the policy doesn't exist
+	DeleteRulesetQualityGate(ctx context.Context, rulesetId string) (bool, error)
+	GetPackageQualityGate(ctx context.Context, packageId string) (*entity.PackageQualityGate, error)
+	// GetPackageQualityGates returns policies of any of the packages
+	GetPackageQualityGates(ctx context.Context, packageIds []string) ([]entity.PackageQualityGate, error)
+	SavePackageQualityGate(ctx context.Context, ent entity.PackageQualityGate) error
+	// DeletePackageQualityGate returns false if the policy doesn't exist
+	DeletePackageQualityGate(ctx context.Context, packageId string) (bool, error)
+}
+
+func NewQualityGateRepository(cp db.ConnectionProvider) QualityGateRepository {
+	return &qualityGateRepositoryImpl{cp: cp}
+}
+
+type qualityGateRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (q qualityGateRepositoryImpl) GetRulesetQualityGate(ctx context.Context, rulesetId string) (*entity.RulesetQualityGate, error) {
+	var result entity.RulesetQualityGate
+	err := q.cp.GetConnection().ModelContext(ctx, &result).
+		Where("ruleset_id = ?", rulesetId).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (q qualityGateRepositoryImpl) GetRulesetQualityGates(ctx context.Context, rulesetIds []string) ([]entity.RulesetQualityGate, error) {
+	var result []entity.RulesetQualityGate
+	if len(rulesetIds) == 0 {
+		return nil, nil
+	}
+	err := q.cp.GetConnection().ModelContext(ctx, &result).
+		Where("ruleset_id in (?)", pg.In(rulesetIds)).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (q qualityGateRepositoryImpl) SaveRulesetQualityGate(ctx context.Context, ent entity.RulesetQualityGate) error {
+	_, err := q.cp.GetConnection().ModelContext(ctx, &ent).OnConflict("(ruleset_id) do update").
+		Set("max_errors = EXCLUDED.max_errors").
+		Set("max_warnings = EXCLUDED.max_warnings").
+		Set("no_new_errors = EXCLUDED.no_new_errors").
+		Set("no_new_warnings = EXCLUDED.no_new_warnings").
+		Set("updated_at = EXCLUDED.updated_at").
+		Set("updated_by = EXCLUDED.updated_by").
+		Insert()
+	return err
+}
+
+func (q qualityGateRepositoryImpl) DeleteRulesetQualityGate(ctx context.Context, rulesetId string) (bool, error) {
+	res, err := q.cp.GetConnection().ModelContext(ctx, (*entity.RulesetQualityGate)(nil)).
+		Where("ruleset_id = ?", rulesetId).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (q qualityGateRepositoryImpl) GetPackageQualityGate(ctx context.Context, packageId string) (*entity.PackageQualityGate, error) {
+	var result entity.PackageQualityGate
+	err := q.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id = ?", packageId).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (q qualityGateRepositoryImpl) GetPackageQualityGates(ctx context.Context, packageIds []string) ([]entity.PackageQualityGate, error) {
+	var result []entity.PackageQualityGate
+	if len(packageIds) == 0 {
+		return nil, nil
+	}
+	err := q.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id in (?)", pg.In(packageIds)).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (q qualityGateRepositoryImpl) SavePackageQualityGate(ctx context.Context, ent entity.PackageQualityGate) error {
+	_, err := q.cp.GetConnection().ModelContext(ctx, &ent).OnConflict("(package_id) do update").
+		Set("max_errors = EXCLUDED.max_errors").
+		Set("max_warnings = EXCLUDED.max_warnings").
+		Set("no_new_errors = EXCLUDED.no_new_errors").
+		Set("no_new_warnings = EXCLUDED.no_new_warnings").
+		Set("updated_at = EXCLUDED.updated_at").
+		Set("updated_by = EXCLUDED.updated_by").
+		Insert()
+	return err
+}
+
+func (q qualityGateRepositoryImpl) DeletePackageQualityGate(ctx context.Context, packageId string) (bool, error) {
+	res, err := q.cp.GetConnection().ModelContext(ctx, (*entity.PackageQualityGate)(nil)).
+		Where("package_id = ?", packageId).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type RelintCampaignRepository interface {
+	CreateCampaign(ctx context.Context, ent entity.RelintCampaign, versions []entity.RelintCampaignVersion) error
+	GetCampaign(ctx context.Context, id string) (*entity.RelintCampaign, error)
+	GetCampaigns(ctx context.Context, rulesetId string) ([]entity.RelintCampaign, error)
+	GetRunningCampaigns(ctx context.Context) ([]entity.RelintCampaign, error)
+	// ClaimCampaign makes the executor responsible for the campaign unless it's processed by another alive executor
+	ClaimCampaign(ctx context.Context, id string, executorId string) (bool, error)
+	UpdateCampaignStatus(ctx context.Context, id string, status view.LintedVersionStatus, details string) error
+	// GetCampaignProgress returns the number of campaign versions in each status
+	GetCampaignProgress(ctx context.Context, id string) (map[view.RelintVersionStatus]int, error)
+	GetPendingVersions(ctx context.Context, id string, limit int) ([]entity.RelintCampaignVersion, error)
+	UpdateCampaignVersion(ctx context.Context, ent entity.RelintCampaignVersion) error
+	// SyncEnqueuedVersions copies the status of finished version lint tasks to the campaign versions
+	SyncEnqueuedVersions(ctx context.Context, id string) error
+	SkipPendingVersions(ctx context.Context, id string, details string) error
+}
+
+// campaign is taken over by another executor if the current one is not active for this time
+const relintCampaignKeepaliveTimeoutSec = 60
+
+func NewRelintCampaignRepository(cp db.ConnectionProvider) RelintCampaignRepository {
+	return &relintCampaignRepositoryImpl{cp: cp}
+}
+
+type relintCampaignRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r relintCampaignRepositoryImpl) CreateCampaign(ctx context.Context, ent entity.RelintCampaign, versions []entity.RelintCampaignVersion) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&ent).Insert()
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return nil
+		}
+		_, err = tx.Model(&versions).Insert()
+		return err
+	})
+}
+
+func (r relintCampaignRepositoryImpl) GetCampaign(ctx context.Context, id string) (*entity.RelintCampaign, error) {
+	var ent entity.RelintCampaign
+	err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Where("id = ?", id).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (r relintCampaignRepositoryImpl) GetCampaigns(ctx context.Context, rulesetId string) ([]entity.RelintCampaign, error) {
+	var result []entity.RelintCampaign
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("ruleset_id = ?", rulesetId).
+		Order("created_at DESC").
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r relintCampaignRepositoryImpl) GetRunningCampaigns(ctx context.Context) ([]entity.RelintCampaign, error) {
+	var result []entity.RelintCampaign
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("status = ?", view.VersionStatusInProgress).
+		Order("created_at ASC").
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r relintCampaignRepositoryImpl) ClaimCampaign(ctx context.Context, id string, executorId string) (bool, error) {
+	var ent entity.RelintCampaign
+	res, err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Set("executor_id = ?", executorId).
+		Set("last_active = ?", time.Now()).
+		Where("id = ?", id).
+		Where("status = ?", view.VersionStatusInProgress).
+		WhereGroup(func(q *pg.Query) (*pg.Query, error) {
+			return q.Where("executor_id is null").
+				WhereOr("executor_id = ?", executorId).
+				WhereOr(fmt.Sprintf("last_active < (now() - interval '%d seconds')", relintCampaignKeepaliveTimeoutSec)), nil
+		}).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (r relintCampaignRepositoryImpl) UpdateCampaignStatus(ctx context.Context, id string, status view.LintedVersionStatus, details string) error {
+	var ent entity.RelintCampaign
+	_, err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Set("status = ?", status).
+		Set("details = ?", details).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Update()
+	return err
+}
+
+func (r relintCampaignRepositoryImpl) GetCampaignProgress(ctx context.Context, id string) (map[view.RelintVersionStatus]int, error) {
+	var rows []struct {
+		Status view.RelintVersionStatus
+		Count  int
+	}
+	_, err := r.cp.GetConnection().QueryContext(ctx, &rows,
+		`select status, count(*) as count from relint_campaign_version where campaign_id = ? group by status`, id)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		return nil, err
+	}
+	result := make(map[view.RelintVersionStatus]int, len(rows))
+	for _, row := range rows {
+		result[row.Status] = row.Count
+	}
+	return result, nil
+}
+
+func (r relintCampaignRepositoryImpl) GetPendingVersions(ctx context.Context, id string, limit int) ([]entity.RelintCampaignVersion, error) {
+	var result []entity.RelintCampaignVersion
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("campaign_id = ?", id).
+		Where("status = ?", view.RelintVersionPending).
+		Order("package_id ASC", "version ASC").
+		Limit(limit).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r relintCampaignRepositoryImpl) UpdateCampaignVersion(ctx context.Context, ent entity.RelintCampaignVersion) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Column("revision", "task_id", "status", "details").
+		WherePK().
+		Update()
+	return err
+}
+
+func (r relintCampaignRepositoryImpl) SyncEnqueuedVersions(ctx context.Context, id string) error {
+	_, err := r.cp.GetConnection().ExecContext(ctx,
+		`update relint_campaign_version cv
+		set status = case when t.status = ? then ? else ? end, details = t.details
+		from version_lint_task t
+		where cv.campaign_id = ? and cv.status = ? and t.id = cv.task_id and t.status in (?, ?)`,
+		view.TaskStatusSuccess, view.RelintVersionSuccess, view.RelintVersionError,
+		id, view.RelintVersionEnqueued, view.TaskStatusSuccess, view.TaskStatusError)
+	return err
+}
+
+func (r relintCampaignRepositoryImpl) SkipPendingVersions(ctx context.Context, id string, details string) error {
+	var ent entity.RelintCampaignVersion
+	_, err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Set("status = ?", view.RelintVersionSkipped).
+		Set("details = ?", details).
+		Where("campaign_id = ?", id).
+		Where("status = ?", view.RelintVersionPending).
+		Update()
+	return err
+}
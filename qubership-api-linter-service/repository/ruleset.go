@@ -13,15 +13,35 @@ import (
 )
 
 type RulesetRepository interface {
-	CreateRuleset(ctx context.Context, ruleset entity.RulesetWithData) error
-	ActivateRuleset(ctx context.Context, id, oldId string) error
+	// CreateRuleset stores the ruleset with additional files and marks its parents as not deletable
+	CreateRuleset(ctx context.Context, ruleset entity.RulesetWithData, files []entity.RulesetFile) error
+	ActivateRuleset(ctx context.Context, id, oldId string, activationType view.ActivationType, scheduledBy string) error
 	ListRulesets(ctx context.Context) ([]entity.Ruleset, error)
 	GetActiveRulesets(ctx context.Context, apiType view.ApiType) (map[view.Linter]entity.Ruleset, error)
 	GetRulesetById(ctx context.Context, id string) (*entity.Ruleset, error)
 	RulesetExists(ctx context.Context, name string, apiType view.ApiType) (bool, error)
+	// GetRulesetByName returns the latest revision of the ruleset
+	GetRulesetByName(ctx context.Context, name string, apiType view.ApiType) (*entity.Ruleset, error)
+	GetRulesetRevision(ctx context.Context, name string, apiType view.ApiType, revision int) (*entity.Ruleset, error)
+	GetRulesetRevisions(ctx context.Context, name string, apiType view.ApiType) ([]entity.Ruleset, error)
+	GetRulesetFiles(ctx context.Context, id string) ([]entity.RulesetFile, error)
+	// GetExtendingRulesets returns rulesets which have the ruleset as a parent
+	GetExtendingRulesets(ctx context.Context, id string) ([]entity.Ruleset, error)
 	GetRulesetWithData(ctx context.Context, id string) (*entity.RulesetWithData, error)
 	GetActivationHistory(ctx context.Context, id string) ([]entity.RulesetActivationHistory, error)
+	// GetPreviousActiveRuleset returns the ruleset which was active for the api type before the current one
+	GetPreviousActiveRuleset(ctx context.Context, apiType view.ApiType, currentId string) (*entity.Ruleset, error)
 	DeleteRuleset(ctx context.Context, id string) error
+
+	CreateActivationSchedule(ctx context.Context, schedule entity.RulesetActivationSchedule) error
+	GetPendingActivationSchedule(ctx context.Context, rulesetId string) (*entity.RulesetActivationSchedule, error)
+	GetActivationSchedules(ctx context.Context, rulesetId string) ([]entity.RulesetActivationSchedule, error)
+	GetDueActivationSchedules(ctx context.Context, now time.Time) ([]entity.RulesetActivationSchedule, error)
+	// CancelActivationSchedule returns false if the schedule is not pending anymore
+	CancelActivationSchedule(ctx context.Context, id string) (bool, error)
+	// ExecuteActivationSchedule activates the scheduled ruleset, returns false if the schedule is not pending anymore or is executed by another instance
+	ExecuteActivationSchedule(ctx context.Context, schedule entity.RulesetActivationSchedule, oldId string) (bool, error)
+	FailActivationSchedule(ctx context.Context, id string, details string) error
 }
 
 func NewRuleSetRepository(cp db.ConnectionProvider) RulesetRepository {
@@ -32,76 +52,102 @@ type ruleSetRepositoryImpl struct {
 	cp db.ConnectionProvider
 }
 
-func (r ruleSetRepositoryImpl) CreateRuleset(ctx context.Context, ruleset entity.RulesetWithData) error {
-	_, err := r.cp.GetConnection().ModelContext(ctx, &ruleset).Insert()
-	return err
+func (r ruleSetRepositoryImpl) CreateRuleset(ctx context.Context, ruleset entity.RulesetWithData, files []entity.RulesetFile) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&ruleset).Insert()
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			_, err = tx.Model(&files).Insert()
+			if err != nil {
+				return err
+			}
+		}
+		if len(ruleset.Parents) > 0 {
+			_, err = tx.Model((*entity.Ruleset)(nil)).
+				Set("can_be_deleted = ?", false).
+				Where("id in (?)", pg.In(ruleset.Parents)).
+				Update()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (r ruleSetRepositoryImpl) ActivateRuleset(ctx context.Context, id, oldId string) error {
+func (r ruleSetRepositoryImpl) ActivateRuleset(ctx context.Context, id, oldId string, activationType view.ActivationType, scheduledBy string) error {
 	user := secctx.GetUserId(ctx)
 	if user == "" {
 		return errors.New("user not found in context")
 	}
 
 	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		// Get old ruleset with pessimistic lock
-		oldRuleset := new(entity.Ruleset)
-		err := tx.Model(oldRuleset).Where("id = ?", oldId).For("UPDATE SKIP LOCKED").Select()
-		if err != nil {
-			return err
-		}
-		if oldRuleset == nil {
-			return fmt.Errorf("concurrect activation detected(current ruleset is locked). please retry")
-		}
-		if oldRuleset.Status != view.RulesetStatusActive {
-			return fmt.Errorf("concurrect activation detected(current ruleset is already inactive). please retry")
-		}
+		return activateRuleset(tx, user, id, oldId, activationType, scheduledBy)
+	})
+}
 
-		// Deactivate currently active ruleset for the same linter and API type
-		_, err = tx.Model(oldRuleset).
-			Set("status = ?", view.RulesetStatusInactive).
-			Where("id = ?", oldId).
-			Update()
-		if err != nil {
-			return err
-		}
+func activateRuleset(tx *pg.Tx, user string, id, oldId string, activationType view.ActivationType, scheduledBy string) error {
+	// Get old ruleset with pessimistic lock
+	oldRuleset := new(entity.Ruleset)
+	err := tx.Model(oldRuleset).Where("id = ?", oldId).For("UPDATE SKIP LOCKED").Select()
+	if err != nil {
+		return err
+	}
+	if oldRuleset == nil {
+		return fmt.Errorf("concurrect activation detected(current ruleset is locked). please retry")
+	}
+	if oldRuleset.Status != view.RulesetStatusActive {
+		return fmt.Errorf("concurrect activation detected(current ruleset is already inactive). please retry")
+	}
 
-		// Activate the new ruleset
-		ruleset := new(entity.Ruleset)
-		_, err = tx.Model(ruleset).
-			Set("status = ?", view.RulesetStatusActive).
-			Set("can_be_deleted = ?", false).
-			Set("last_activated = ?", time.Now()).
-			Where("id = ?", id).
-			Update()
-		if err != nil {
-			return err
-		}
+	// Deactivate currently active ruleset for the same linter and API type
+	_, err = tx.Model(oldRuleset).
+		Set("status = ?", view.RulesetStatusInactive).
+		Where("id = ?", oldId).
+		Update()
+	if err != nil {
+		return err
+	}
 
-		// Update activation history for old ruleset
-		_, err = tx.Model((*entity.RulesetActivationHistory)(nil)).
-			Set("deactivated_at = ?", time.Now()).
-			Set("deactivated_by = ?", user).
-			Where("ruleset_id = ?", oldId).
-			Where("deactivated_at is null").
-			Update()
-		if err != nil {
-			return err
-		}
+	// Activate the new ruleset
+	ruleset := new(entity.Ruleset)
+	_, err = tx.Model(ruleset).
+		Set("status = ?", view.RulesetStatusActive).
+		Set("can_be_deleted = ?", false).
+		Set("last_activated = ?", time.Now()).
+		Where("id = ?", id).
+		Update()
+	if err != nil {
+		return err
+	}
 
-		// Add activation history item for new active ruleset
-		history := &entity.RulesetActivationHistory{
-			RulesetId:   id,
-			ActivatedAt: time.Now(),
-			ActivatedBy: user,
-		}
-		_, err = tx.Model(history).Insert()
-		if err != nil {
-			return err
-		}
+	// Update activation history for old ruleset
+	_, err = tx.Model((*entity.RulesetActivationHistory)(nil)).
+		Set("deactivated_at = ?", time.Now()).
+		Set("deactivated_by = ?", user).
+		Where("ruleset_id = ?", oldId).
+		Where("deactivated_at is null").
+		Update()
+	if err != nil {
+		return err
+	}
 
-		return nil
-	})
+	// Add activation history item for new active ruleset
+	history := &entity.RulesetActivationHistory{
+		RulesetId:      id,
+		ActivatedAt:    time.Now(),
+		ActivatedBy:    user,
+		ActivationType: activationType,
+		ScheduledBy:    scheduledBy,
+	}
+	_, err = tx.Model(history).Insert()
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (r ruleSetRepositoryImpl) ListRulesets(ctx context.Context) ([]entity.Ruleset, error) {
@@ -164,6 +210,75 @@ func (r ruleSetRepositoryImpl) RulesetExists(ctx context.Context, name string, a
 	return ruleset != nil, nil
 }
 
+func (r ruleSetRepositoryImpl) GetRulesetByName(ctx context.Context, name string, apiType view.ApiType) (*entity.Ruleset, error) {
+	var ruleset entity.Ruleset
+	err := r.cp.GetConnection().ModelContext(ctx, &ruleset).
+		Where("name = ?", name).
+		Where("api_type = ?", apiType).
+		Order("revision DESC").
+		Limit(1).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ruleset, nil
+}
+
+func (r ruleSetRepositoryImpl) GetRulesetRevision(ctx context.Context, name string, apiType view.ApiType, revision int) (*entity.Ruleset, error) {
+	var ruleset entity.Ruleset
+	err := r.cp.GetConnection().ModelContext(ctx, &ruleset).
+		Where("name = ?", name).
+		Where("api_type = ?", apiType).
+		Where("revision = ?", revision).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ruleset, nil
+}
+
+func (r ruleSetRepositoryImpl) GetRulesetRevisions(ctx context.Context, name string, apiType view.ApiType) ([]entity.Ruleset, error) {
+	var rulesets []entity.Ruleset
+	err := r.cp.GetConnection().ModelContext(ctx, &rulesets).
+		Where("name = ?", name).
+		Where("api_type = ?", apiType).
+		Order("revision DESC").
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	return rulesets, err
+}
+
+func (r ruleSetRepositoryImpl) GetRulesetFiles(ctx context.Context, id string) ([]entity.RulesetFile, error) {
+	var files []entity.RulesetFile
+	err := r.cp.GetConnection().ModelContext(ctx, &files).
+		Where("ruleset_id = ?", id).
+		Order("path").
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	return files, err
+}
+
+func (r ruleSetRepositoryImpl) GetExtendingRulesets(ctx context.Context, id string) ([]entity.Ruleset, error) {
+	var rulesets []entity.Ruleset
+	err := r.cp.GetConnection().ModelContext(ctx, &rulesets).
+		Where("? = any(parents)", id).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	return rulesets, err
+}
+
 func (r ruleSetRepositoryImpl) GetRulesetWithData(ctx context.Context, id string) (*entity.RulesetWithData, error) {
 	var ruleset entity.RulesetWithData
 	err := r.cp.GetConnection().ModelContext(ctx, &ruleset).
@@ -188,6 +303,22 @@ func (r ruleSetRepositoryImpl) GetActivationHistory(ctx context.Context, id stri
 	return history, err
 }
 
+func (r ruleSetRepositoryImpl) GetPreviousActiveRuleset(ctx context.Context, apiType view.ApiType, currentId string) (*entity.Ruleset, error) {
+	var ruleset entity.Ruleset
+	_, err := r.cp.GetConnection().QueryOneContext(ctx, &ruleset, `select r.* from ruleset r
+		join ruleset_activation_history h on h.ruleset_id = r.id
+		where r.api_type = ? and r.id != ? and h.deactivated_at is not null
+		order by h.deactivated_at desc
+		limit 1`, apiType, currentId)
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ruleset, nil
+}
+
 func (r ruleSetRepositoryImpl) DeleteRuleset(ctx context.Context, id string) error {
 	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
 		var ruleset entity.Ruleset
@@ -207,6 +338,132 @@ func (r ruleSetRepositoryImpl) DeleteRuleset(ctx context.Context, id string) err
 		_, err = tx.Model(&ruleset).
 			Where("id = ?", id).
 			Delete()
+		if err != nil {
+			return err
+		}
+
+		// parents which are not in use anymore can be deleted as well
+		if len(ruleset.Parents) > 0 {
+			_, err = tx.Exec(`update ruleset set can_be_deleted = true
+				where id in (?) and status = ? and last_activated is null
+				and not exists (select 1 from ruleset r where ruleset.id = any(r.parents))`,
+				pg.In(ruleset.Parents), view.RulesetStatusInactive)
+		}
 		return err
 	})
 }
+
+func (r ruleSetRepositoryImpl) CreateActivationSchedule(ctx context.Context, schedule entity.RulesetActivationSchedule) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, &schedule).Insert()
+	return err
+}
+
+func (r ruleSetRepositoryImpl) GetPendingActivationSchedule(ctx context.Context, rulesetId string) (*entity.RulesetActivationSchedule, error) {
+	var schedule entity.RulesetActivationSchedule
+	err := r.cp.GetConnection().ModelContext(ctx, &schedule).
+		Where("ruleset_id = ?", rulesetId).
+		Where("status = ?", view.ActivationScheduled).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r ruleSetRepositoryImpl) GetActivationSchedules(ctx context.Context, rulesetId string) ([]entity.RulesetActivationSchedule, error) {
+	var schedules []entity.RulesetActivationSchedule
+	err := r.cp.GetConnection().ModelContext(ctx, &schedules).
+		Where("ruleset_id = ?", rulesetId).
+		Order("created_at DESC").
+		Limit(100).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	return schedules, err
+}
+
+func (r ruleSetRepositoryImpl) GetDueActivationSchedules(ctx context.Context, now time.Time) ([]entity.RulesetActivationSchedule, error) {
+	var schedules []entity.RulesetActivationSchedule
+	err := r.cp.GetConnection().ModelContext(ctx, &schedules).
+		Where("status = ?", view.ActivationScheduled).
+		Where("activate_at <= ?", now).
+		Order("activate_at").
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	return schedules, err
+}
+
+func (r ruleSetRepositoryImpl) CancelActivationSchedule(ctx context.Context, id string) (bool, error) {
+	user := secctx.GetUserId(ctx)
+	if user == "" {
+		return false, errors.New("user not found in context")
+	}
+	res, err := r.cp.GetConnection().ModelContext(ctx, (*entity.RulesetActivationSchedule)(nil)).
+		Set("status = ?", view.ActivationScheduleCancelled).
+		Set("cancelled_at = ?", time.Now()).
+		Set("cancelled_by = ?", user).
+		Where("id = ?", id).
+		Where("status = ?", view.ActivationScheduled).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (r ruleSetRepositoryImpl) ExecuteActivationSchedule(ctx context.Context, schedule entity.RulesetActivationSchedule, oldId string) (bool, error) {
+	user := secctx.GetUserId(ctx)
+	if user == "" {
+		return false, errors.New("user not found in context")
+	}
+	executed := false
+	err := r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		// the lock prevents execution by several instances and concurrent cancellation
+		var pending entity.RulesetActivationSchedule
+		err := tx.Model(&pending).
+			Where("id = ?", schedule.Id).
+			Where("status = ?", view.ActivationScheduled).
+			For("UPDATE SKIP LOCKED").
+			Select()
+		if err != nil {
+			if errors.Is(err, pg.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		err = activateRuleset(tx, user, pending.RulesetId, oldId, view.ActivationTypeScheduled, pending.CreatedBy)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&pending).
+			Set("status = ?", view.ActivationScheduleCompleted).
+			Set("executed_at = ?", time.Now()).
+			WherePK().
+			Update()
+		if err != nil {
+			return err
+		}
+		executed = true
+		return nil
+	})
+	return executed, err
+}
+
+func (r ruleSetRepositoryImpl) FailActivationSchedule(ctx context.Context, id string, details string) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, (*entity.RulesetActivationSchedule)(nil)).
+		Set("status = ?", view.ActivationScheduleFailed).
+		Set("details = ?", details).
+		Set("executed_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("status = ?", view.ActivationScheduled).
+		Update()
+	return err
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type RulesetBindingRepository interface {
+	// GetBindings returns bindings of the api type for any of the packages
+	GetBindings(ctx context.Context, packageIds []string, apiType view.ApiType) ([]entity.RulesetBinding, error)
+	ListPackageBindings(ctx context.Context, packageId string) ([]entity.RulesetBinding, error)
+	// BindRuleset replaces the existing binding for the package and api type
+	BindRuleset(ctx context.Context, binding entity.RulesetBinding) error
+	// UnbindRuleset returns false if the binding doesn't exist
+	UnbindRuleset(ctx context.Context, packageId string, apiType view.ApiType) (bool, error)
+	GetBindingHistory(ctx context.Context, packageId string) ([]entity.RulesetBindingHistory, error)
+}
+
+func NewRulesetBindingRepository(cp db.ConnectionProvider) RulesetBindingRepository {
+	return &rulesetBindingRepositoryImpl{cp: cp}
+}
+
+type rulesetBindingRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r rulesetBindingRepositoryImpl) GetBindings(ctx context.Context, packageIds []string, apiType view.ApiType) ([]entity.RulesetBinding, error) {
+	var result []entity.RulesetBinding
+	if len(packageIds) == 0 {
+		return nil, nil
+	}
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id in (?)", pg.In(packageIds)).
+		Where("api_type = ?", apiType).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r rulesetBindingRepositoryImpl) ListPackageBindings(ctx context.Context, packageId string) ([]entity.RulesetBinding, error) {
+	var result []entity.RulesetBinding
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id = ?", packageId).
+		Order("api_type").
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r rulesetBindingRepositoryImpl) BindRuleset(ctx context.Context, binding entity.RulesetBinding) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := closeBindingHistory(tx, binding.PackageId, binding.ApiType, binding.CreatedBy)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&binding).OnConflict("(package_id, api_type) do update").
+			Set("ruleset_id = EXCLUDED.ruleset_id").
+			Set("created_at = EXCLUDED.created_at").
+			Set("created_by = EXCLUDED.created_by").
+			Insert()
+		if err != nil {
+			return err
+		}
+
+		// bound ruleset is in use, so it can't be deleted as well as activated one
+		_, err = tx.Model((*entity.Ruleset)(nil)).
+			Set("can_be_deleted = ?", false).
+			Set("last_activated = ?", binding.CreatedAt).
+			Where("id = ?", binding.RulesetId).
+			Update()
+		if err != nil {
+			return err
+		}
+
+		history := &entity.RulesetBindingHistory{
+			PackageId:   binding.PackageId,
+			ApiType:     binding.ApiType,
+			RulesetId:   binding.RulesetId,
+			ActivatedAt: binding.CreatedAt,
+			ActivatedBy: binding.CreatedBy,
+		}
+		_, err = tx.Model(history).Insert()
+		return err
+	})
+}
+
+func (r rulesetBindingRepositoryImpl) UnbindRuleset(ctx context.Context, packageId string, apiType view.ApiType) (bool, error) {
+	deleted := false
+	err := r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		res, err := tx.Model((*entity.RulesetBinding)(nil)).
+			Where("package_id = ?", packageId).
+			Where("api_type = ?", apiType).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return nil
+		}
+		deleted = true
+		return closeBindingHistory(tx, packageId, apiType, secctx.GetUserId(ctx))
+	})
+	return deleted, err
+}
+
+func (r rulesetBindingRepositoryImpl) GetBindingHistory(ctx context.Context, packageId string) ([]entity.RulesetBindingHistory, error) {
+	var history []entity.RulesetBindingHistory
+	err := r.cp.GetConnection().ModelContext(ctx, &history).
+		Where("package_id = ?", packageId).
+		Order("activated_at DESC").
+		Limit(100).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	return history, err
+}
+
+func closeBindingHistory(tx *pg.Tx, packageId string, apiType view.ApiType, user string) error {
+	_, err := tx.Model((*entity.RulesetBindingHistory)(nil)).
+		Set("deactivated_at = ?", time.Now()).
+		Set("deactivated_by = ?", user).
+		Where("package_id = ?", packageId).
+		Where("api_type = ?", apiType).
+		Where("deactivated_at is null").
+		Update()
+	return err
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type RulesetImpactRepository interface {
+	SaveImpactReport(ctx context.Context, ent entity.RulesetImpactReport) error
+	// UpdateImpactReport saves the progress and the status of the report
+	UpdateImpactReport(ctx context.Context, ent entity.RulesetImpactReport) error
+	GetImpactReport(ctx context.Context, id string) (*entity.RulesetImpactReport, error)
+}
+
+func NewRulesetImpactRepository(cp db.ConnectionProvider) RulesetImpactRepository {
+	return &rulesetImpactRepositoryImpl{cp: cp}
+}
+
+type rulesetImpactRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r rulesetImpactRepositoryImpl) SaveImpactReport(ctx context.Context, ent entity.RulesetImpactReport) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, &ent).Insert()
+	return err
+}
+
+func (r rulesetImpactRepositoryImpl) UpdateImpactReport(ctx context.Context, ent entity.RulesetImpactReport) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Column("status", "details", "updated_at", "packages_total", "packages_processed", "packages").
+		WherePK().
+		Update()
+	return err
+}
+
+func (r rulesetImpactRepositoryImpl) GetImpactReport(ctx context.Context, id string) (*entity.RulesetImpactReport, error) {
+	var ent entity.RulesetImpactReport
+	err := r.cp.GetConnection().ModelContext(ctx, &ent).
+		Where("id = ?", id).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ent, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// Postgres NOTIFY channels used to wake up task processors without waiting for the polling interval
+const (
+	VersionTaskCreatedChannel = "linter_version_task_created"
+	DocTasksCreatedChannel    = "linter_doc_tasks_created"
+	DocTaskFinishedChannel    = "linter_doc_task_finished"
+)
+
+// notify sends the notification via the db or transaction, in case of transaction it's delivered on commit only
+func notify(ctx context.Context, conn orm.DB, channel string, payload string) error {
+	_, err := conn.ExecContext(ctx, "select pg_notify(?, ?)", channel, payload)
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Netcracker/qubership-api-linter-service/exception"
 	"github.com/Netcracker/qubership-api-linter-service/view"
 	"github.com/go-pg/pg/v10"
+	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 	"time"
@@ -101,6 +102,11 @@ func (r *versionLintTaskRepositoryImpl) SaveVersionTask(ctx context.Context, ent
 		}
 		return err
 	}
+	err = notify(ctx, r.cp.GetConnection(), VersionTaskCreatedChannel, ent.Id)
+	if err != nil {
+		// the task is saved anyway and will be picked up by polling
+		log.Warnf("Failed to send notification for version lint task %s: %s", ent.Id, err)
+	}
 	return nil
 }
 
@@ -231,7 +237,7 @@ func (r *versionLintTaskRepositoryImpl) GetRunningTaskForVersion(ctx context.Con
 
 var queryVersionTask = fmt.Sprintf("select * from version_lint_task b where "+
 	"(b.status='%s' or ((b.status='%s' or b.status='%s') and b.last_active < (now() - interval '%d seconds'))) "+
-	"order by b.created_at ASC limit 1 for no key update skip locked", view.TaskStatusNotStarted, view.TaskStatusProcessing, view.TaskStatusWaitingForDocs, buildKeepaliveTimeoutSec)
+	"order by "+taskPriorityOrder+" limit 1 for no key update skip locked", view.TaskStatusNotStarted, view.TaskStatusProcessing, view.TaskStatusWaitingForDocs, buildKeepaliveTimeoutSec)
 
 func (r *versionLintTaskRepositoryImpl) FindFreeVersionTask(ctx context.Context, executorId string) (*entity.VersionLintTask, error) {
 	var result *entity.VersionLintTask
@@ -2,16 +2,27 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/Netcracker/qubership-api-linter-service/db"
 	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/go-pg/pg/v10"
 )
 
 type VersionResultRepository interface {
 	GetLintedVersion(ctx context.Context, packageId, version string, revision int) (*entity.LintedVersion, error)
 	GetVersionAndDocsSummary(ctx context.Context, packageId, version string, revision int) (*entity.LintedVersion, []entity.LintedDocument, error)
 	GetLintedDocument(ctx context.Context, packageId, version string, revision int, slug string) (*entity.LintedDocument, error)
+	// GetLatestLintedVersions returns the latest successfully linted version of each package with documents of the api type.
+	// Random packages are returned if limit > 0.
+	GetLatestLintedVersions(ctx context.Context, apiType view.ApiType, limit int) ([]entity.LintedVersion, error)
+	// GetRecentLintedVersions returns up to versionsPerPackage most recently linted versions of each package with documents of the api type.
+	// Only the latest linted revision of each version is returned.
+	GetRecentLintedVersions(ctx context.Context, apiType view.ApiType, versionsPerPackage int) ([]entity.LintedVersion, error)
+	// GetVersionsWithQualityGate returns linted versions of the package which have the quality gate verdict
+	GetVersionsWithQualityGate(ctx context.Context, packageId string) ([]entity.LintedVersion, error)
+	// UpdateQualityGateVerdict saves the verdict of the version unless the version was linted again, returns false in this case
+	UpdateQualityGateVerdict(ctx context.Context, ver entity.LintedVersion) (bool, error)
 }
 
 func NewVersionResultRepository(cp db.ConnectionProvider) VersionResultRepository {
@@ -30,7 +41,7 @@ func (v versionResultRepositoryImpl) GetLintedVersion(ctx context.Context, packa
 		Where("revision = ?", revision).
 		Select()
 	if err != nil {
-		if errors.As(err, &sql.ErrNoRows) {
+		if errors.Is(err, pg.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -48,7 +59,7 @@ func (v versionResultRepositoryImpl) GetVersionAndDocsSummary(ctx context.Contex
 		Where("revision = ?", revision).
 		Select()
 	if err != nil {
-		if errors.As(err, &sql.ErrNoRows) {
+		if errors.Is(err, pg.ErrNoRows) {
 			return nil, nil, nil
 		}
 		return nil, nil, err
@@ -62,7 +73,7 @@ func (v versionResultRepositoryImpl) GetVersionAndDocsSummary(ctx context.Contex
 		Order("slug ASC").
 		Select()
 	if err != nil {
-		if errors.As(err, &sql.ErrNoRows) {
+		if errors.Is(err, pg.ErrNoRows) {
 			return nil, nil, nil
 		}
 		return nil, nil, err
@@ -80,7 +91,7 @@ func (v versionResultRepositoryImpl) GetLintedDocument(ctx context.Context, pack
 		Where("slug = ?", slug).
 		Select()
 	if err != nil {
-		if errors.As(err, &sql.ErrNoRows) {
+		if errors.Is(err, pg.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -88,3 +99,82 @@ func (v versionResultRepositoryImpl) GetLintedDocument(ctx context.Context, pack
 
 	return &doc, nil
 }
+
+func (v versionResultRepositoryImpl) GetLatestLintedVersions(ctx context.Context, apiType view.ApiType, limit int) ([]entity.LintedVersion, error) {
+	var result []entity.LintedVersion
+	query := `select * from (
+		select distinct on (lv.package_id) lv.* from linted_version lv
+		where lv.lint_status = ?
+		and exists(select 1 from linted_document ld
+			where ld.package_id = lv.package_id and ld.version = lv.version and ld.revision = lv.revision
+			and ld.specification_type = ?)
+		order by lv.package_id, lv.linted_at desc
+	) latest`
+	params := []interface{}{view.VersionStatusSuccess, apiType}
+	if limit > 0 {
+		query += ` order by random() limit ?`
+		params = append(params, limit)
+	} else {
+		query += ` order by package_id`
+	}
+	_, err := v.cp.GetConnection().QueryContext(ctx, &result, query, params...)
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionResultRepositoryImpl) GetRecentLintedVersions(ctx context.Context, apiType view.ApiType, versionsPerPackage int) ([]entity.LintedVersion, error) {
+	var result []entity.LintedVersion
+	query := `select package_id, version, revision, lint_status, lint_details, linted_at from (
+		select lv.*, row_number() over (partition by lv.package_id order by lv.linted_at desc) as rn
+		from (
+			select distinct on (lv.package_id, lv.version) lv.* from linted_version lv
+			where exists(select 1 from linted_document ld
+				where ld.package_id = lv.package_id and ld.version = lv.version and ld.revision = lv.revision
+				and ld.specification_type = ?)
+			order by lv.package_id, lv.version, lv.revision desc
+		) lv
+	) recent
+	where rn <= ?
+	order by package_id, linted_at desc`
+	_, err := v.cp.GetConnection().QueryContext(ctx, &result, query, apiType, versionsPerPackage)
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionResultRepositoryImpl) GetVersionsWithQualityGate(ctx context.Context, packageId string) ([]entity.LintedVersion, error) {
+	var result []entity.LintedVersion
+	err := v.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id = ?", packageId).
+		Where("quality_gate_status is not null and quality_gate_status != ''").
+		Order("version ASC", "revision ASC").
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionResultRepositoryImpl) UpdateQualityGateVerdict(ctx context.Context, ver entity.LintedVersion) (bool, error) {
+	res, err := v.cp.GetConnection().ModelContext(ctx, &ver).
+		Column("quality_gate_status", "quality_gate_reasons").
+		WherePK().
+		Where("linted_at = ?linted_at").
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
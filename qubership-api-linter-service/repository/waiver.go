@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type WaiverRepository interface {
+	SaveWaiver(ctx context.Context, ent entity.IssueWaiver) error
+	GetWaiver(ctx context.Context, packageId string, waiverId string) (*entity.IssueWaiver, error)
+	// GetWaivers returns the waivers of the package, the latest first
+	GetWaivers(ctx context.Context, packageId string, activeOnly bool) ([]entity.IssueWaiver, error)
+	// RevokeWaiver returns false if the waiver doesn't exist or is already revoked
+	RevokeWaiver(ctx context.Context, packageId string, waiverId string, revokedBy string) (bool, error)
+}
+
+func NewWaiverRepository(cp db.ConnectionProvider) WaiverRepository {
+	return &waiverRepositoryImpl{cp: cp}
+}
+
+type waiverRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (w waiverRepositoryImpl) SaveWaiver(ctx context.Context, ent entity.IssueWaiver) error {
+	_, err := w.cp.GetConnection().ModelContext(ctx, &ent).Insert()
+	return err
+}
+
+func (w waiverRepositoryImpl) GetWaiver(ctx context.Context, packageId string, waiverId string) (*entity.IssueWaiver, error) {
+	var result entity.IssueWaiver
+	err := w.cp.GetConnection().ModelContext(ctx, &result).
+		Where("id = ?", waiverId).
+		Where("package_id = ?", packageId).
+		Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (w waiverRepositoryImpl) GetWaivers(ctx context.Context, packageId string, activeOnly bool) ([]entity.IssueWaiver, error) {
+	var result []entity.IssueWaiver
+	query := w.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id = ?", packageId)
+	if activeOnly {
+		query = query.Where("revoked_at is null").
+			Where("expires_at > ?", time.Now())
+	}
+	err := query.Order("created_at DESC").Select()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (w waiverRepositoryImpl) RevokeWaiver(ctx context.Context, packageId string, waiverId string, revokedBy string) (bool, error) {
+	res, err := w.cp.GetConnection().ModelContext(ctx, (*entity.IssueWaiver)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Set("revoked_by = ?", revokedBy).
+		Where("id = ?", waiverId).
+		Where("package_id = ?", packageId).
+		Where("revoked_at is null").
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
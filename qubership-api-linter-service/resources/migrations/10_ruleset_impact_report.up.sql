@@ -0,0 +1,20 @@
+-- comparison of issue counts for the candidate ruleset and the rulesets currently used by packages
+create table ruleset_impact_report
+(
+    id                 varchar
+        constraint ruleset_impact_report_pk primary key,
+    ruleset_id         varchar                     not null
+        constraint ruleset_impact_report_ruleset_id_fk
+            references ruleset (id) on delete cascade,
+    status             varchar                     not null,
+    details            varchar,
+    created_at         timestamp without time zone not null,
+    created_by         varchar                     not null,
+    updated_at         timestamp without time zone not null,
+    packages_total     integer                     not null,
+    packages_processed integer                     not null,
+    packages           jsonb                       not null
+);
+
+create index ruleset_impact_report_ruleset_id_index
+    on ruleset_impact_report (ruleset_id);
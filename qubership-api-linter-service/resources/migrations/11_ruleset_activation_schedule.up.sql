@@ -0,0 +1,33 @@
+-- activations planned for a future time, executed by any instance of the service
+create table ruleset_activation_schedule
+(
+    id           varchar
+        constraint ruleset_activation_schedule_pk primary key,
+    ruleset_id   varchar                     not null
+        constraint ruleset_activation_schedule_ruleset_id_fk
+            references ruleset (id) on delete cascade,
+    activate_at  timestamp without time zone not null,
+    status       varchar                     not null,
+    details      varchar,
+    created_at   timestamp without time zone not null,
+    created_by   varchar                     not null,
+    cancelled_at timestamp without time zone,
+    cancelled_by varchar,
+    executed_at  timestamp without time zone
+);
+
+create index ruleset_activation_schedule_ruleset_id_index
+    on ruleset_activation_schedule (ruleset_id);
+
+create index ruleset_activation_schedule_activate_at_index
+    on ruleset_activation_schedule (activate_at) where status = 'scheduled';
+
+-- only one pending activation per ruleset
+create unique index ruleset_activation_schedule_pending_uindex
+    on ruleset_activation_schedule (ruleset_id) where status = 'scheduled';
+
+-- how the ruleset was activated: manual, scheduled or rollback
+alter table ruleset_activation_history
+    add column activation_type varchar not null default 'manual';
+alter table ruleset_activation_history
+    add column scheduled_by varchar;
@@ -0,0 +1,9 @@
+-- rulesets with the same name and api type are revisions of one lineage
+alter table ruleset
+    add column revision integer not null default 1;
+
+alter table ruleset
+    drop constraint ruleset_name_unique;
+
+alter table ruleset
+    add constraint ruleset_name_revision_unique unique (name, api_type, revision);
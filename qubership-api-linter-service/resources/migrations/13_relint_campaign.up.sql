@@ -0,0 +1,40 @@
+-- re-lint of recently linted versions after ruleset activation, processed by any instance of the service
+create table relint_campaign
+(
+    id                   varchar
+        constraint relint_campaign_pk primary key,
+    ruleset_id           varchar                     not null
+        constraint relint_campaign_ruleset_id_fk
+            references ruleset (id) on delete cascade,
+    status               varchar                     not null,
+    details              varchar,
+    versions_per_package integer                     not null,
+    created_at           timestamp without time zone not null,
+    created_by           varchar                     not null,
+    updated_at           timestamp without time zone not null,
+    executor_id          varchar,
+    last_active          timestamp without time zone
+);
+
+create index relint_campaign_ruleset_id_index
+    on relint_campaign (ruleset_id);
+
+create table relint_campaign_version
+(
+    campaign_id varchar not null
+        constraint relint_campaign_version_campaign_id_fk
+            references relint_campaign (id) on delete cascade,
+    package_id  varchar not null,
+    version     varchar not null,
+    revision    integer not null,
+    task_id     varchar,
+    status      varchar not null,
+    details     varchar,
+    constraint relint_campaign_version_pk primary key (campaign_id, package_id, version)
+);
+
+create index relint_campaign_version_status_index
+    on relint_campaign_version (campaign_id, status);
+
+alter table ruleset_activation_schedule
+    add column relint boolean not null default false;
@@ -0,0 +1,18 @@
+-- lint issues of the version comparing to the previous version, computed when the version lint is completed
+create table version_lint_diff
+(
+    package_id          varchar                     not null,
+    version             varchar                     not null,
+    revision            integer                     not null,
+    previous_package_id varchar                     not null,
+    previous_version    varchar                     not null,
+    previous_revision   integer                     not null,
+    -- lint time of both versions, the diff is outdated if any of them is linted again
+    linted_at           timestamp without time zone not null,
+    previous_linted_at  timestamp without time zone not null,
+    created_at          timestamp without time zone not null,
+    summary             jsonb                       not null,
+    documents           jsonb                       not null,
+    constraint version_lint_diff_pk
+        primary key (package_id, version, revision)
+);
@@ -0,0 +1,32 @@
+-- quality gate policies, the package policy (or the closest parent group or workspace policy) overrides ruleset policies
+create table ruleset_quality_gate
+(
+    ruleset_id      varchar
+        constraint ruleset_quality_gate_pk primary key
+        constraint ruleset_quality_gate_ruleset_id_fk
+            references ruleset (id) on delete cascade,
+    max_errors      integer,
+    max_warnings    integer,
+    no_new_errors   boolean                     not null default false,
+    no_new_warnings boolean                     not null default false,
+    updated_at      timestamp without time zone not null,
+    updated_by      varchar                     not null
+);
+
+create table package_quality_gate
+(
+    package_id      varchar
+        constraint package_quality_gate_pk primary key,
+    max_errors      integer,
+    max_warnings    integer,
+    no_new_errors   boolean                     not null default false,
+    no_new_warnings boolean                     not null default false,
+    updated_at      timestamp without time zone not null,
+    updated_by      varchar                     not null
+);
+
+-- verdict is evaluated when the version lint completes, null if no policy is applicable
+alter table linted_version
+    add column quality_gate_status varchar;
+alter table linted_version
+    add column quality_gate_reasons jsonb;
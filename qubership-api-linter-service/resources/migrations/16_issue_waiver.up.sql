@@ -0,0 +1,21 @@
+-- accepted violations of the package, waived issues are shown separately and are not checked by quality gates
+create table issue_waiver
+(
+    id            varchar
+        constraint issue_waiver_pk primary key,
+    package_id    varchar                     not null,
+    version_from  varchar,
+    version_to    varchar,
+    slug          varchar,
+    rule_code     varchar,
+    path          varchar,
+    justification varchar                     not null,
+    expires_at    timestamp without time zone not null,
+    created_at    timestamp without time zone not null,
+    created_by    varchar                     not null,
+    revoked_at    timestamp without time zone,
+    revoked_by    varchar
+);
+
+create index issue_waiver_package_id_index
+    on issue_waiver (package_id);
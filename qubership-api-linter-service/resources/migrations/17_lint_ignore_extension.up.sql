@@ -0,0 +1,3 @@
+-- suppression extension which was applied to the stored report, results filtered with another extension are not reused
+alter table lint_file_result
+    add column lint_ignore_extension varchar default '' not null;
@@ -0,0 +1,3 @@
+-- stored diffs matched issues with array indexes ignored, which merged issues of numeric keys like response codes,
+-- they are computed again on request
+delete from version_lint_diff;
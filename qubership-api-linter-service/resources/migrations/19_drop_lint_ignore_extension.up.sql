@@ -0,0 +1,4 @@
+-- lint results are not keyed by the suppression extension since it's not a part of the primary key:
+-- the upsert overwrote results which were still referenced by documents linted with another extension
+alter table lint_file_result
+    drop column if exists lint_ignore_extension;
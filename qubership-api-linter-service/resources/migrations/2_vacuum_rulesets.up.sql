@@ -0,0 +1,12 @@
+insert into ruleset
+values ('0b6a1c5e-4f1d-4a43-9d39-2f3c8a7e2d10', 'default-vacuum-openapi-2-0', 'inactive',
+        'extends: [[spectral:oas, recommended]]'::BYTEA, now(), 'system', 'openapi-2-0', 'vacuum',
+        'default-vacuum-openapi-2-0.yaml', false);
+insert into ruleset
+values ('5d8e3f27-1c6b-4e0a-8b52-7a9f4c1d3e62', 'default-vacuum-openapi-3-0', 'inactive',
+        'extends: [[spectral:oas, recommended]]'::BYTEA, now(), 'system', 'openapi-3-0', 'vacuum',
+        'default-vacuum-openapi-3-0.yaml', false);
+insert into ruleset
+values ('a9c47b13-6e2f-4d85-b0a1-3c5e8f9d7b24', 'default-vacuum-openapi-3-1', 'inactive',
+        'extends: [[spectral:oas, recommended]]'::BYTEA, now(), 'system', 'openapi-3-1', 'vacuum',
+        'default-vacuum-openapi-3-1.yaml', false);
@@ -0,0 +1,13 @@
+insert into ruleset
+values ('7f2e9c41-8b3d-4a6e-9f15-c2d4e6a8b031', 'default-asyncapi-2', 'active',
+        'extends: [[spectral:asyncapi, recommended]]'::BYTEA, now(), 'system', 'asyncapi-2', 'spectral',
+        'default-asyncapi-2.yaml', false);
+insert into ruleset
+values ('c61d0a58-2e47-4b9c-a3f8-5d7b9e1c4f26', 'default-asyncapi-3', 'active',
+        'extends: [[spectral:asyncapi, recommended]]'::BYTEA, now(), 'system', 'asyncapi-3', 'spectral',
+        'default-asyncapi-3.yaml', false);
+
+insert into ruleset_activation_history
+values ('7f2e9c41-8b3d-4a6e-9f15-c2d4e6a8b031', now(), 'system', null, '');
+insert into ruleset_activation_history
+values ('c61d0a58-2e47-4b9c-a3f8-5d7b9e1c4f26', now(), 'system', null, '');
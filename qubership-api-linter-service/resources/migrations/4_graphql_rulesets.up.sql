@@ -0,0 +1,7 @@
+insert into ruleset
+values ('3e8b5d92-7a14-4c6f-b2d9-e0f1a3c5b784', 'default-graphql', 'active',
+        '{"rules": ["defined-types-are-used", "deprecations-have-a-reason", "enum-values-all-caps", "fields-are-camel-cased", "input-object-values-are-camel-cased", "types-are-capitalized"]}'::BYTEA,
+        now(), 'system', 'graphql', 'graphql-schema-linter', 'default-graphql.json', false);
+
+insert into ruleset_activation_history
+values ('3e8b5d92-7a14-4c6f-b2d9-e0f1a3c5b784', now(), 'system', null, '');
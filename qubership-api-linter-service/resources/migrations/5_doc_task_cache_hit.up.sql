@@ -0,0 +1,2 @@
+alter table document_lint_task
+    add column cache_hit boolean default false not null;
@@ -0,0 +1,8 @@
+alter table document_lint_task
+    add column apihub_checksum varchar;
+
+alter table linted_document
+    add column apihub_checksum varchar;
+
+create index linted_document_apihub_checksum_index
+    on linted_document (apihub_checksum);
@@ -0,0 +1,14 @@
+create table draft_lint_report
+(
+    id         varchar
+        constraint draft_lint_report_pk primary key,
+    package_id varchar                     not null,
+    created_by varchar                     not null,
+    created_at timestamp without time zone not null,
+    expires_at timestamp without time zone not null,
+    summary    jsonb                       not null,
+    documents  jsonb                       not null
+);
+
+create index draft_lint_report_expires_at_index
+    on draft_lint_report (expires_at);
@@ -0,0 +1,29 @@
+-- rulesets bound to a package, group or workspace override the globally active ruleset for the api type
+create table ruleset_binding
+(
+    package_id varchar                     not null,
+    api_type   varchar                     not null,
+    ruleset_id varchar                     not null
+        constraint ruleset_binding_ruleset_id_fk
+            references ruleset (id),
+    created_at timestamp without time zone not null,
+    created_by varchar                     not null,
+    constraint ruleset_binding_pk
+        primary key (package_id, api_type)
+);
+
+create table ruleset_binding_history
+(
+    package_id     varchar                     not null,
+    api_type       varchar                     not null,
+    ruleset_id     varchar                     not null
+        constraint ruleset_binding_history_ruleset_id_fk
+            references ruleset (id),
+    activated_at   timestamp without time zone not null,
+    activated_by   varchar                     not null,
+    deactivated_at timestamp without time zone,
+    deactivated_by varchar
+);
+
+create index ruleset_binding_history_package_id_index
+    on ruleset_binding_history (package_id);
@@ -0,0 +1,18 @@
+-- ids of the rulesets extended by the ruleset, in the order of declaration
+alter table ruleset
+    add column parents varchar[] default '{}' not null;
+
+create index ruleset_parents_index
+    on ruleset using gin (parents);
+
+-- additional ruleset files, e.g. custom functions
+create table ruleset_file
+(
+    ruleset_id varchar not null
+        constraint ruleset_file_ruleset_id_fk
+            references ruleset (id) on delete cascade,
+    path       varchar not null,
+    data       bytea   not null,
+    constraint ruleset_file_pk
+        primary key (ruleset_id, path)
+);
@@ -144,36 +144,99 @@ func main() {
 	docResultRepository := repository.NewDocResultRepository(cp)
 	versionResultRepository := repository.NewVersionResultRepository(cp)
 	lintResultRepository := repository.NewLintResultRepository(cp)
+	draftLintReportRepository := repository.NewDraftLintReportRepository(cp)
+	rulesetBindingRepository := repository.NewRulesetBindingRepository(cp)
+	rulesetImpactRepository := repository.NewRulesetImpactRepository(cp)
+	relintCampaignRepository := repository.NewRelintCampaignRepository(cp)
+	lintDiffRepository := repository.NewLintDiffRepository(cp)
+	qualityGateRepository := repository.NewQualityGateRepository(cp)
+	waiverRepository := repository.NewWaiverRepository(cp)
+
+	spectralExecutor, err := service.NewSpectralExecutor(systemInfoService.GetSpectralBinPath(), systemInfoService.GetLinterConcurrency())
+	if err != nil {
+		log.Fatalf("Failed to create Spectral executor: %s", err.Error())
+	}
+	var vacuumExecutor service.LinterExecutor
+	if systemInfoService.GetVacuumBinPath() != "" {
+		vacuumExecutor, err = service.NewVacuumExecutor(systemInfoService.GetVacuumBinPath(), systemInfoService.GetLinterConcurrency())
+		if err != nil {
+			log.Fatalf("Failed to create Vacuum executor: %s", err.Error())
+		}
+	} else {
+		log.Infof("%s env is not set, vacuum linter is disabled", service.VACUUM_BIN_PATH)
+	}
+	var graphqlExecutor service.LinterExecutor
+	if systemInfoService.GetGraphqlLinterBinPath() != "" {
+		graphqlExecutor, err = service.NewGraphqlExecutor(systemInfoService.GetGraphqlLinterBinPath(), systemInfoService.GetLinterConcurrency())
+		if err != nil {
+			log.Fatalf("Failed to create GraphQL linter executor: %s", err.Error())
+		}
+	} else {
+		log.Infof("%s env is not set, graphql linter is disabled", service.GRAPHQL_LINTER_BIN_PATH)
+	}
+	linterRegistry := service.NewLinterRegistry(spectralExecutor, vacuumExecutor, graphqlExecutor)
+
+	linterSelectorService := service.NewLinterSelectorService(ruleSetRepository, rulesetBindingRepository, apihubClient, linterRegistry)
+
+	taskEventsService := service.NewTaskEventsService(cp)
 
-	linterSelectorService := service.NewLinterSelectorService(ruleSetRepository)
+	rulesetMaterializer := service.NewRulesetMaterializer(ruleSetRepository)
+	docTaskProcessor := service.NewDocTaskProcessor(docLintTaskRepository, ruleSetRepository, docResultRepository, apihubClient, linterRegistry, rulesetMaterializer, taskEventsService, systemInfoService.GetLintIgnoreExtension(), systemInfoService.GetDocTaskWorkers(), executorId)
 
-	versionTaskProcessor := service.NewVersionTaskProcessor(versionLintTaskRepository, docLintTaskRepository, versionResultRepository, apihubClient, linterSelectorService, executorId)
-	spectralExecutor, err := service.NewSpectralExecutor(systemInfoService.GetSpectralBinPath())
+	ruleCatalogService, err := service.NewRuleCatalogService(ruleSetRepository, basePath+"/resources/rule-catalog")
 	if err != nil {
-		log.Fatalf("Failed to create Spectral executor: %s", err.Error())
+		log.Fatalf("Failed to load rule catalog: %s", err.Error())
 	}
 
-	docTaskProcessor := service.NewDocTaskProcessor(docLintTaskRepository, ruleSetRepository, docResultRepository, apihubClient, spectralExecutor, executorId)
+	lintDiffService := service.NewLintDiffService(lintDiffRepository, versionResultRepository, lintResultRepository, ruleSetRepository, linterRegistry, ruleCatalogService, apihubClient)
+	qualityGateService := service.NewQualityGateService(qualityGateRepository, ruleSetRepository, versionResultRepository, lintResultRepository, waiverRepository, linterRegistry, lintDiffService, apihubClient)
+	waiverService := service.NewWaiverService(waiverRepository, qualityGateService, apihubClient)
+	versionTaskProcessor := service.NewVersionTaskProcessor(versionLintTaskRepository, docLintTaskRepository, versionResultRepository, apihubClient, linterSelectorService, taskEventsService, lintDiffService, qualityGateService, executorId)
 
-	validationService := service.NewValidationService(versionLintTaskRepository, versionResultRepository, lintResultRepository, ruleSetRepository, docLintTaskRepository, versionTaskProcessor, apihubClient, executorId)
+	validationService := service.NewValidationService(versionLintTaskRepository, versionResultRepository, lintResultRepository, ruleSetRepository, docLintTaskRepository, versionTaskProcessor, linterRegistry, ruleCatalogService, waiverService, apihubClient, executorId)
+	sarifExportService := service.NewSarifExportService(versionResultRepository, lintResultRepository, ruleSetRepository, linterRegistry, ruleCatalogService, waiverService, apihubClient)
 	publishEventListener := service.NewPublishEventListener(olricProvider, validationService)
-	rulesetService := service.NewRulesetService(ruleSetRepository)
+	relintCampaignService := service.NewRelintCampaignService(relintCampaignRepository, ruleSetRepository, versionResultRepository, versionLintTaskRepository,
+		linterSelectorService, apihubClient, systemInfoService.GetRelintVersionsPerPackage(), systemInfoService.GetRelintMaxActiveTasks(), executorId)
+	rulesetService := service.NewRulesetService(ruleSetRepository, linterRegistry, rulesetMaterializer, relintCampaignService)
+	rulesetDryRunService := service.NewRulesetDryRunService(ruleSetRepository, apihubClient, linterRegistry, rulesetMaterializer, ruleCatalogService, systemInfoService.GetLintIgnoreExtension())
+	rulesetImpactService := service.NewRulesetImpactService(rulesetImpactRepository, ruleSetRepository, versionResultRepository, lintResultRepository,
+		linterSelectorService, linterRegistry, rulesetMaterializer, apihubClient, systemInfoService.GetLintIgnoreExtension())
+	rulesetBindingService := service.NewRulesetBindingService(rulesetBindingRepository, ruleSetRepository, apihubClient, linterRegistry)
 	cleanupService := service.NewCleanupService(cp)
+	lintService := service.NewLintService(ruleSetRepository, linterSelectorService, linterRegistry, rulesetMaterializer, ruleCatalogService, systemInfoService.GetLintIgnoreExtension())
+	draftValidationService := service.NewDraftValidationService(draftLintReportRepository, ruleSetRepository, linterSelectorService, linterRegistry, rulesetMaterializer, ruleCatalogService, systemInfoService.GetLintIgnoreExtension(), systemInfoService.GetDraftReportTtl())
 	authorizationService := service.NewAuthorizationService(apihubClient)
 
 	validationController := controller.NewValidationController(validationService, authorizationService)
 
-	validationResultController := controller.NewValidationResultController(validationService, authorizationService)
+	validationResultController := controller.NewValidationResultController(validationService, lintDiffService, sarifExportService, authorizationService)
 
-	rulesetController := controller.NewRulesetController(rulesetService, authorizationService)
+	rulesetController := controller.NewRulesetController(rulesetService, rulesetDryRunService, ruleCatalogService, relintCampaignService, authorizationService, linterRegistry)
+	rulesetImpactController := controller.NewRulesetImpactController(rulesetImpactService, authorizationService)
+	rulesetBindingController := controller.NewRulesetBindingController(rulesetBindingService, authorizationService)
+	qualityGateController := controller.NewQualityGateController(qualityGateService, authorizationService)
+	waiverController := controller.NewWaiverController(waiverService, authorizationService)
+	lintController := controller.NewLintController(lintService, authorizationService)
+	draftValidationController := controller.NewDraftValidationController(draftValidationService, authorizationService)
 	cleanupController := controller.NewCleanupController(cleanupService, authorizationService, systemInfoService)
 	healthController := controller.NewHealthController(readyChan)
 
 	// Validate version
 	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/validation", security.Secure(validationController.ValidateVersion)).Methods(http.MethodPost)
 
+	// Draft validation
+	r.HandleFunc("/api/v1/packages/{packageId}/drafts/validation", security.Secure(draftValidationController.ValidateDraft)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/packages/{packageId}/drafts/validation/{draftId}/summary", security.Secure(draftValidationController.GetDraftValidationSummary)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/drafts/validation/{draftId}/documents/{slug}/details", security.Secure(draftValidationController.GetDraftValidationResultForDocument)).Methods(http.MethodGet)
+
+	// Ad-hoc lint
+	r.HandleFunc("/api/v1/lint", security.Secure(lintController.LintDocument)).Methods(http.MethodPost)
+
 	// Validation result
 	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/validation/summary", security.Secure(validationResultController.GetValidationSummaryForVersion)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/validation/diff", security.Secure(validationResultController.GetValidationDiffForVersion)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/validation/sarif", security.Secure(validationResultController.GetValidationSarifForVersion)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/validation/documents/{slug}/details", security.Secure(validationResultController.GetValidationResultForDocument)).Methods(http.MethodGet)
 
 	// Ruleset management
@@ -183,7 +246,36 @@ func main() {
 	r.HandleFunc("/api/v1/rulesets/{ruleset_id}", security.Secure(rulesetController.GetRuleset)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/data", security.NoSecure(rulesetController.GetRulesetData)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/activation", security.Secure(rulesetController.GetRulesetActivationHistory)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/activation/schedule", security.Secure(rulesetController.CancelScheduledActivation)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/activation/rollback", security.Secure(rulesetController.RollbackActivation)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v1/rulesets/{ruleset_id}", security.Secure(rulesetController.DeleteRuleset)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/dry-run", security.Secure(rulesetController.DryRunRuleset)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/revisions", security.Secure(rulesetController.ListRulesetRevisions)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/diff", security.Secure(rulesetController.GetRulesetDiff)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/rules", security.Secure(rulesetController.GetRuleCatalog)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/relint", security.Secure(rulesetController.ListRelintCampaigns)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/relint/{campaign_id}", security.Secure(rulesetController.GetRelintCampaign)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/qualityGate", security.Secure(qualityGateController.GetRulesetQualityGate)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/qualityGate", security.Secure(qualityGateController.SetRulesetQualityGate)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/qualityGate", security.Secure(qualityGateController.DeleteRulesetQualityGate)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/impact", security.Secure(rulesetImpactController.StartImpactReport)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/rulesets/{ruleset_id}/impact/{report_id}", security.Secure(rulesetImpactController.GetImpactReport)).Methods(http.MethodGet)
+
+	// Ruleset bindings to package, group or workspace
+	r.HandleFunc("/api/v1/packages/{packageId}/rulesets", security.Secure(rulesetBindingController.ListRulesetBindings)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/rulesets/activation", security.Secure(rulesetBindingController.GetRulesetBindingHistory)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/rulesets/{apiType}", security.Secure(rulesetBindingController.BindRuleset)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/packages/{packageId}/rulesets/{apiType}", security.Secure(rulesetBindingController.UnbindRuleset)).Methods(http.MethodDelete)
+
+	// Quality gate policy of package, group or workspace
+	r.HandleFunc("/api/v1/packages/{packageId}/qualityGate", security.Secure(qualityGateController.GetPackageQualityGate)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/qualityGate", security.Secure(qualityGateController.SetPackageQualityGate)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/packages/{packageId}/qualityGate", security.Secure(qualityGateController.DeletePackageQualityGate)).Methods(http.MethodDelete)
+
+	// Accepted violations of the package
+	r.HandleFunc("/api/v1/packages/{packageId}/waivers", security.Secure(waiverController.ListWaivers)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/waivers", security.Secure(waiverController.CreateWaiver)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/packages/{packageId}/waivers/{waiverId}", security.Secure(waiverController.RevokeWaiver)).Methods(http.MethodDelete)
 
 	// Test data cleanup
 	r.HandleFunc("/api/internal/clear/{testId}", security.Secure(cleanupController.ClearTestData)).Methods(http.MethodDelete)
@@ -195,6 +287,7 @@ func main() {
 
 	publishEventListener.Start()
 	docTaskProcessor.Start()
+	taskEventsService.Start()
 
 	knownPathPrefixes := []string{
 		"/api/",
@@ -11,9 +11,11 @@ type AuthorizationService interface {
 	HasRulesetReadPermission(ctx context.Context) (bool, error)
 	HasRulesetListPermission(ctx context.Context) (bool, error)
 	HasRulesetManagementPermission(ctx context.Context) (bool, error)
+	HasAdHocLintPermission(ctx context.Context) (bool, error)
 
 	HasReadPackagePermission(ctx context.Context, packageId string) (bool, error)
 	HasPublishPackagePermission(ctx context.Context, packageId string) (bool, error)
+	HasRulesetBindingManagementPermission(ctx context.Context, packageId string) (bool, error)
 }
 
 func NewAuthorizationService(apihubClient client.ApihubClient) AuthorizationService {
@@ -36,6 +38,10 @@ func (a authorizationServiceImpl) HasRulesetManagementPermission(ctx context.Con
 	return secctx.IsSysadm(ctx), nil
 }
 
+func (a authorizationServiceImpl) HasAdHocLintPermission(ctx context.Context) (bool, error) {
+	return true, nil // No restrictions at this moment
+}
+
 func (a authorizationServiceImpl) HasReadPackagePermission(ctx context.Context, packageId string) (bool, error) {
 	if secctx.IsSysadm(ctx) {
 		return true, nil
@@ -74,3 +80,24 @@ func (a authorizationServiceImpl) HasPublishPackagePermission(ctx context.Contex
 	}
 	return false, nil
 }
+
+func (a authorizationServiceImpl) HasRulesetBindingManagementPermission(ctx context.Context, packageId string) (bool, error) {
+	if secctx.IsSysadm(ctx) {
+		return true, nil
+	}
+	roles, err := a.apihubClient.GetAvailableRoles(ctx, packageId)
+	if err != nil {
+		return false, err
+	}
+	if roles == nil {
+		return false, nil
+	}
+	for _, role := range roles.Roles {
+		for _, perm := range role.Permissions {
+			if perm == view.CreateAndUpdatePackagePermission {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"expvar"
 	"fmt"
 	"github.com/Netcracker/qubership-api-linter-service/client"
 	"github.com/Netcracker/qubership-api-linter-service/entity"
@@ -13,7 +13,6 @@ import (
 	log "github.com/sirupsen/logrus"
 	"os"
 	"path/filepath"
-	"sync/atomic"
 	"time"
 )
 
@@ -22,13 +21,21 @@ type DocTaskProcessor interface {
 }
 
 func NewDocTaskProcessor(docTaskRepo repository.DocLintTaskRepository, ruleSetRepository repository.RulesetRepository,
-	docResultRepository repository.DocResultRepository, cl client.ApihubClient, spectralExecutor SpectralExecutor, executorId string) DocTaskProcessor {
+	docResultRepository repository.DocResultRepository, cl client.ApihubClient, linterRegistry LinterRegistry, rulesetMaterializer RulesetMaterializer,
+	taskEventsService TaskEventsService, lintIgnoreExtension string, workersCount int, executorId string) DocTaskProcessor {
+	if workersCount < 1 {
+		workersCount = 1
+	}
 	return &docTaskProcessorImpl{
 		docTaskRepo:         docTaskRepo,
 		ruleSetRepository:   ruleSetRepository,
 		docResultRepository: docResultRepository,
 		cl:                  cl,
-		spectralExecutor:    spectralExecutor,
+		linterRegistry:      linterRegistry,
+		rulesetMaterializer: rulesetMaterializer,
+		taskEventsService:   taskEventsService,
+		lintIgnoreExtension: lintIgnoreExtension,
+		workersCount:        workersCount,
 		executorId:          executorId,
 	}
 }
@@ -38,41 +45,77 @@ type docTaskProcessorImpl struct {
 	ruleSetRepository   repository.RulesetRepository
 	docResultRepository repository.DocResultRepository
 	cl                  client.ApihubClient
-	spectralExecutor    SpectralExecutor
+	linterRegistry      LinterRegistry
+	rulesetMaterializer RulesetMaterializer
+	taskEventsService   TaskEventsService
+	lintIgnoreExtension string
 
-	executorId string
+	workersCount int
+	executorId   string
 }
 
-// TODO: maybe need some fast track
-// TODO: read from ticker chan or from events chan
-
-func (d docTaskProcessorImpl) Start() {
-	// TODO: multiple threads or not?
+const (
+	docTaskIdleBackoffMin = time.Second
+	docTaskIdleBackoffMax = time.Second * 10
+)
 
-	utils.SafeAsync(func() {
-		ticker := time.NewTicker(time.Second * 5)
+var (
+	docTaskWorkersTotal = expvar.NewInt("doc_task_workers_total")
+	docTaskWorkersBusy  = expvar.NewInt("doc_task_workers_busy")
+	docTasksProcessed   = expvar.NewInt("doc_tasks_processed")
+	docTaskCacheHits    = expvar.NewInt("doc_task_cache_hits")
+)
 
-		running := atomic.Bool{}
+func init() {
+	expvar.Publish("doc_task_pool_saturation", expvar.Func(func() interface{} {
+		total := docTaskWorkersTotal.Value()
+		if total == 0 {
+			return float64(0)
+		}
+		return float64(docTaskWorkersBusy.Value()) / float64(total)
+	}))
+}
 
-		for range ticker.C {
-			if running.Load() {
-				log.Tracef("docTaskProcessorImpl: ticker skipped, running")
-				continue
-			}
+func (d docTaskProcessorImpl) Start() {
+	docTaskWorkersTotal.Add(int64(d.workersCount))
+	for i := 0; i < d.workersCount; i++ {
+		workerNum := i
+		// every worker has own subscription to wake up all idle workers when a bunch of tasks is created
+		tasksCreatedC := d.taskEventsService.Subscribe(repository.DocTasksCreatedChannel)
+		utils.SafeAsync(func() {
+			d.runWorker(workerNum, tasksCreatedC)
+		})
+	}
+	log.Infof("Started %d doc task workers", d.workersCount)
+}
 
-			utils.SafeAsync(func() {
-				running.Store(true)
-				for {
-					moreWork := d.processTask()
-					if moreWork == false {
-						break
-					}
-					log.Tracef("docTaskProcessorImpl: keep on running")
-				}
-				running.Store(false)
-			})
+// runWorker claims tasks one by one while the queue is not empty, then backs off until the next attempt or new tasks notification
+func (d docTaskProcessorImpl) runWorker(workerNum int, tasksCreatedC <-chan struct{}) {
+	backoff := docTaskIdleBackoffMin
+	for {
+		moreWork := false
+		utils.SafeSync(func() {
+			moreWork = d.processTask()
+		})
+		if moreWork {
+			backoff = docTaskIdleBackoffMin
+			log.Tracef("docTaskProcessorImpl: worker %d keeps on running", workerNum)
+			continue
 		}
-	})
+		idleTimer := time.NewTimer(backoff)
+		select {
+		case <-idleTimer.C:
+		case <-tasksCreatedC:
+			idleTimer.Stop()
+			backoff = docTaskIdleBackoffMin
+			log.Tracef("docTaskProcessorImpl: worker %d woken up by new doc tasks", workerNum)
+			continue
+		}
+		backoff *= 2
+		if backoff > docTaskIdleBackoffMax {
+			backoff = docTaskIdleBackoffMax
+		}
+	}
 }
 
 func (d docTaskProcessorImpl) processTask() bool {
@@ -82,8 +125,15 @@ func (d docTaskProcessorImpl) processTask() bool {
 		return false
 	}
 	if task != nil {
+		docTaskWorkersBusy.Add(1)
+		if busy := docTaskWorkersBusy.Value(); busy >= int64(d.workersCount) {
+			log.Debugf("Doc task worker pool is saturated: %d of %d workers are busy", busy, d.workersCount)
+		}
+		defer docTaskWorkersBusy.Add(-1)
+
 		d.processDocTask(secctx.MakeSysadminContext(context.Background()), *task)
 		d.writeAsyncTestLog(task.Id)
+		docTasksProcessed.Add(1)
 		return true
 	}
 	return false
@@ -103,6 +153,7 @@ func (d docTaskProcessorImpl) handleError(ctx context.Context, task entity.Docum
 		DataHash:          "", // set to empty string because in some error cases it is not available
 		LintStatus:        view.StatusError,
 		LintDetails:       err.Error(),
+		ApihubChecksum:    task.ApihubChecksum,
 	}
 
 	verEnt := entity.LintedVersion{
@@ -115,15 +166,13 @@ func (d docTaskProcessorImpl) handleError(ctx context.Context, task entity.Docum
 	}
 
 	err = d.docResultRepository.SaveLintResult(ctx, task.Id, view.StatusError, err.Error(),
-		lintTimeMs, verEnt, docEnt, nil, d.executorId)
+		lintTimeMs, false, verEnt, docEnt, nil, d.executorId)
 	if err != nil {
 		log.Errorf("Handle error for doc task %s failed: unable to save lint result: %s", task.Id, err)
 	}
 }
 
 func (d docTaskProcessorImpl) processDocTask(ctx context.Context, task entity.DocumentLintTask) {
-	// TODO : hash could be in DocumentLintTask, it will allow to avoid downloading the doc and further processing
-	// TODO: shortcut by hash here? or validate anyway?
 	start := time.Now()
 
 	runningC := make(chan struct{})
@@ -134,27 +183,41 @@ func (d docTaskProcessorImpl) processDocTask(ctx context.Context, task entity.Do
 	// Update last_active during long run
 	utils.SafeAsync(func() {
 		t := time.NewTicker(time.Second * 5)
-		select {
-		case <-ctx.Done():
-			t.Stop()
-			break
-		case _, ok := <-t.C:
-			if !ok {
-				t.Stop()
-				break
-			}
-			err := d.docTaskRepo.SetDocTaskStatus(ctx, task.Id, view.TaskStatusProcessing, "", d.executorId)
-			if err != nil {
-				log.Errorf("Error updating status of doc task %s: %s", task.Id, err)
-			}
-		case _, ok := <-runningC:
-			if !ok {
-				t.Stop()
-				break
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-runningC:
+				return
+			case <-t.C:
+				err := d.docTaskRepo.SetDocTaskStatus(ctx, task.Id, view.TaskStatusProcessing, "", d.executorId)
+				if err != nil {
+					log.Errorf("Error updating status of doc task %s: %s", task.Id, err)
+				}
 			}
 		}
 	})
 
+	linter, err := d.linterRegistry.GetLinter(task.Linter)
+	if err != nil {
+		d.handleError(ctx, task, fmt.Errorf("selected linter %s is not supported", task.Linter), time.Since(start).Milliseconds())
+		return
+	}
+	linterVersion := linter.GetLinterVersion()
+	log.Tracef("%s linter version is %s", task.Linter, linterVersion)
+
+	if task.ApihubChecksum != "" {
+		// the document with the same content could be already linted, no need to download it
+		cachedHash, err := d.docResultRepository.FindLintResultHashByChecksum(ctx, task.ApihubChecksum, task.RulesetId, linterVersion)
+		if err != nil {
+			log.Warnf("Failed to find lint result by checksum for doc %s (task id = %s), going to download it: %s", task.FileId, task.Id, err)
+		} else if cachedHash != "" {
+			d.saveCachedResult(ctx, task, cachedHash, start)
+			return
+		}
+	}
+
 	data, err := d.cl.GetDocumentRawData(ctx, task.PackageId, fmt.Sprintf("%s@%d", task.Version, task.Revision), task.FileSlug)
 	if err != nil {
 		d.handleError(ctx, task, err, time.Since(start).Milliseconds())
@@ -182,121 +245,143 @@ func (d docTaskProcessorImpl) processDocTask(ctx context.Context, task entity.Do
 
 	docHash := utils.CreateSHA256Hash(data)
 
+	// the same document could be already linted with the same ruleset, e.g. in the previous revision
+	resultExists, err := d.docResultRepository.LintResultExists(ctx, docHash, task.RulesetId, linterVersion)
+	if err != nil {
+		log.Warnf("Failed to check existing lint result for doc %s (task id = %s), going to lint it: %s", task.FileId, task.Id, err)
+	} else if resultExists {
+		d.saveCachedResult(ctx, task, docHash, start)
+		return
+	}
+
 	rs, err := d.ruleSetRepository.GetRulesetWithData(ctx, task.RulesetId)
 	if err != nil {
 		d.handleError(ctx, task, fmt.Errorf("error getting ruleset: %s", err), time.Since(start).Milliseconds())
 		return
 	}
-	rsExt := filepath.Ext(rs.FileName)
-	rulesetFileName := "ruleset" + rsExt // Some linters (e.g. Spectral) have a problem with some characters is file names, so generating a safe one.
-	rulesetPath := filepath.Join(tempDir, rulesetFileName)
-	if err := os.WriteFile(rulesetPath, rs.Data, 0600); err != nil {
-		d.handleError(ctx, task, fmt.Errorf("error writing ruleset file: %s", err), time.Since(start).Milliseconds())
+	if rs == nil {
+		d.handleError(ctx, task, fmt.Errorf("ruleset with id %s not found", task.RulesetId), time.Since(start).Milliseconds())
+		return
+	}
+	// parents and custom functions of the ruleset are written to the ruleset subdirectory, apart from the document
+	rulesetPath, err := d.rulesetMaterializer.Materialize(ctx, *rs, tempDir)
+	if err != nil {
+		d.handleError(ctx, task, fmt.Errorf("error writing ruleset: %s", err), time.Since(start).Milliseconds())
 		return
 	}
 
 	status := view.StatusSuccess
 	details := ""
-	var result []byte
-	var report []interface{}
-	var summary view.SpectralResultSummary
 	var sumAsMap map[string]interface{}
 
-	if task.Linter == view.SpectralLinter {
-		// it might take a long time due to linter lock or just long execution
+	// it might take a long time due to linter lock or just long execution
+	log.Infof("Processing doc %s (task id = %s) for package %s, version %s@%d by %s", task.FileId, task.Id, task.PackageId, task.Version, task.Revision, task.Linter)
+	result, calcTime, err := linter.LintLocalDoc(filePath, rulesetPath)
+	if err != nil {
+		status = view.StatusError
+		details = fmt.Sprintf("error linting doc with %s: %s", task.Linter, err)
+	}
 
-		log.Infof("Processing doc %s (task id = %s) for package %s, version %s@%d by spectral", task.FileId, task.Id, task.PackageId, task.Version, task.Revision)
-		resultPath, calcTime, err := d.spectralExecutor.LintLocalDoc(filePath, rulesetPath)
+	var suppressed map[string]int
+	if status == view.StatusSuccess {
+		result, suppressed, err = suppressIgnoredIssues(linter, d.lintIgnoreExtension, data, result)
 		if err != nil {
 			status = view.StatusError
-			details = fmt.Sprintf("error linting doc with spectral: %s", err)
+			details = err.Error()
 		}
+	}
 
-		if status == view.StatusSuccess {
-			result, err = os.ReadFile(resultPath)
-			if err != nil {
-				status = view.StatusError
-				details = fmt.Sprintf("error reading result file: %s", err)
-			}
-			log.Tracef("result file size is %d bytes", len(result))
+	if status == view.StatusSuccess {
+		sumAsMap, err = linter.CalculateSummary(result)
+		if err != nil {
+			status = view.StatusError
+			details = err.Error()
+		} else if len(suppressed) > 0 {
+			sumAsMap[suppressedIssuesSummaryKey] = suppressed
 		}
+	}
 
-		if status == view.StatusSuccess {
-			err = json.Unmarshal(result, &report)
-			if err != nil {
-				status = view.StatusError
-				details = fmt.Sprintf("error unmarshalling result: %s", err)
-			}
-		}
+	logDetails := ""
+	if details != "" {
+		logDetails = fmt.Sprintf("details = %s, ", details)
+	}
+	log.Infof("Lint finished for doc %s (task id = %s), status = %s, %sProcessing time = %+vms", task.FileId, task.Id, status, logDetails, calcTime)
 
-		if status == view.StatusSuccess {
-			summary = calculateSpectralSummary(report)
+	docEnt := entity.LintedDocument{
+		PackageId:         task.PackageId,
+		Version:           task.Version,
+		Revision:          task.Revision,
+		Slug:              task.FileSlug,
+		FileId:            task.FileId,
+		SpecificationType: task.APIType,
+		RulesetId:         task.RulesetId,
+		DataHash:          docHash,
+		LintStatus:        status,
+		LintDetails:       details,
+		ApihubChecksum:    task.ApihubChecksum,
+	}
 
-			sumJson, err := json.Marshal(summary)
-			if err != nil {
-				status = view.StatusError
-				details = fmt.Sprintf("error marshaling summary: %s", err)
-			} else {
-				err = json.Unmarshal(sumJson, &sumAsMap)
-				if err != nil {
-					status = view.StatusError
-					details = fmt.Sprintf("error unmarshaling summary: %s", err)
-				}
-			}
-		}
+	verEnt := entity.LintedVersion{
+		PackageId:   task.PackageId,
+		Version:     task.Version,
+		Revision:    task.Revision,
+		LintStatus:  view.VersionStatusInProgress,
+		LintDetails: "",
+		LintedAt:    time.Now(),
+	}
 
-		logDetails := ""
-		if details != "" {
-			logDetails = fmt.Sprintf("details = %s, ", details)
-		}
-		log.Infof("Lint finished for doc %s (task id = %s), status = %s, %sProcessing time = %+vms", task.FileId, task.Id, status, logDetails, calcTime)
-
-		LinterVersion := d.spectralExecutor.GetLinterVersion()
-		log.Tracef("Spectral linter version is %s", LinterVersion)
-
-		docEnt := entity.LintedDocument{
-			PackageId:         task.PackageId,
-			Version:           task.Version,
-			Revision:          task.Revision,
-			Slug:              task.FileSlug,
-			FileId:            task.FileId,
-			SpecificationType: task.APIType,
-			RulesetId:         task.RulesetId,
-			DataHash:          docHash,
-			LintStatus:        status,
-			LintDetails:       details,
-		}
+	var lintFileResult *entity.LintFileResult
 
-		verEnt := entity.LintedVersion{
-			PackageId:   task.PackageId,
-			Version:     task.Version,
-			Revision:    task.Revision,
-			LintStatus:  view.VersionStatusInProgress,
-			LintDetails: "",
-			LintedAt:    time.Now(),
+	if status == view.StatusSuccess {
+		lintFileResult = &entity.LintFileResult{
+			DataHash:      docHash,
+			RulesetId:     task.RulesetId,
+			LinterVersion: linterVersion,
+			Data:          result,
+			Summary:       sumAsMap,
 		}
+	}
+
+	err = d.docResultRepository.SaveLintResult(context.Background(), task.Id, status, details, calcTime, false, verEnt, docEnt, lintFileResult, d.executorId)
+	if err != nil {
+		d.handleError(ctx, task, fmt.Errorf("failed to save lint result with error: %s", err), time.Since(start).Milliseconds())
+		return
+	}
+}
 
-		var lintFileResult *entity.LintFileResult
+// saveCachedResult completes the task with the existing lint result for the same document data, ruleset, linter version and suppression extension
+func (d docTaskProcessorImpl) saveCachedResult(ctx context.Context, task entity.DocumentLintTask, docHash string, start time.Time) {
+	docEnt := entity.LintedDocument{
+		PackageId:         task.PackageId,
+		Version:           task.Version,
+		Revision:          task.Revision,
+		Slug:              task.FileSlug,
+		FileId:            task.FileId,
+		SpecificationType: task.APIType,
+		RulesetId:         task.RulesetId,
+		DataHash:          docHash,
+		LintStatus:        view.StatusSuccess,
+		LintDetails:       "",
+		ApihubChecksum:    task.ApihubChecksum,
+	}
 
-		if status == view.StatusSuccess {
-			lintFileResult = &entity.LintFileResult{
-				DataHash:      docHash,
-				RulesetId:     task.RulesetId,
-				LinterVersion: LinterVersion,
-				Data:          result,
-				Summary:       sumAsMap,
-			}
-		}
+	verEnt := entity.LintedVersion{
+		PackageId:   task.PackageId,
+		Version:     task.Version,
+		Revision:    task.Revision,
+		LintStatus:  view.VersionStatusInProgress,
+		LintDetails: "",
+		LintedAt:    time.Now(),
+	}
 
-		err = d.docResultRepository.SaveLintResult(context.Background(), task.Id, status, details, calcTime, verEnt, docEnt, lintFileResult, d.executorId)
-		if err != nil {
-			d.handleError(ctx, task, fmt.Errorf("failed to save lint result with error: %s", err), time.Since(start).Milliseconds())
-			return
-		}
-	} else {
-		d.handleError(ctx, task, fmt.Errorf("selected linter %s is not supported", task.Linter), time.Since(start).Milliseconds())
+	processingTime := time.Since(start).Milliseconds()
+	err := d.docResultRepository.SaveLintResult(ctx, task.Id, view.StatusSuccess, "", processingTime, true, verEnt, docEnt, nil, d.executorId)
+	if err != nil {
+		d.handleError(ctx, task, fmt.Errorf("failed to save cached lint result with error: %s", err), time.Since(start).Milliseconds())
 		return
 	}
+	docTaskCacheHits.Add(1)
+	log.Infof("Lint result reused for doc %s (task id = %s) with hash %s, Processing time = %dms", task.FileId, task.Id, docHash, processingTime)
 }
 
 // TODO: temp! just for testing!
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DraftValidationService lints not published documents (e.g. from APIHUB branch editor) and keeps the report for a limited time
+type DraftValidationService interface {
+	ValidateDraft(ctx context.Context, packageId string, docs []view.DraftDocument) (*view.DraftValidationSummary, error)
+	GetDraftSummary(ctx context.Context, packageId string, draftId string) (*view.DraftValidationSummary, error)
+	GetDraftDocumentResult(ctx context.Context, packageId string, draftId string, slug string) (*view.DocumentResult, error)
+}
+
+func NewDraftValidationService(draftRepository repository.DraftLintReportRepository, rulesetRepository repository.RulesetRepository,
+	linterSelectorService LinterSelectorService, linterRegistry LinterRegistry, rulesetMaterializer RulesetMaterializer, ruleCatalogService RuleCatalogService,
+	lintIgnoreExtension string, reportTtl time.Duration) DraftValidationService {
+	svc := &draftValidationServiceImpl{
+		draftRepository:       draftRepository,
+		rulesetRepository:     rulesetRepository,
+		linterSelectorService: linterSelectorService,
+		linterRegistry:        linterRegistry,
+		rulesetMaterializer:   rulesetMaterializer,
+		ruleCatalogService:    ruleCatalogService,
+		lintIgnoreExtension:   lintIgnoreExtension,
+		reportTtl:             reportTtl,
+	}
+
+	utils.SafeAsync(func() {
+		svc.cleanupExpiredReports()
+	})
+
+	return svc
+}
+
+type draftValidationServiceImpl struct {
+	draftRepository       repository.DraftLintReportRepository
+	rulesetRepository     repository.RulesetRepository
+	linterSelectorService LinterSelectorService
+	linterRegistry        LinterRegistry
+	rulesetMaterializer   RulesetMaterializer
+	ruleCatalogService    RuleCatalogService
+	lintIgnoreExtension   string
+	reportTtl             time.Duration
+}
+
+type draftRuleset struct {
+	linter  LinterExecutor
+	ruleset entity.RulesetWithData
+}
+
+func (d draftValidationServiceImpl) ValidateDraft(ctx context.Context, packageId string, docs []view.DraftDocument) (*view.DraftValidationSummary, error) {
+	start := time.Now()
+
+	slugs := makeDraftSlugs(docs)
+	apiTypes := make([]view.ApiType, len(docs))
+	rulesets := make(map[view.ApiType]*draftRuleset)
+	for i, doc := range docs {
+		apiType := detectApiType(doc.FileName, doc.Data)
+		apiTypes[i] = apiType
+		if apiType == "" {
+			continue
+		}
+		if _, exists := rulesets[apiType]; exists {
+			continue
+		}
+		rs, err := d.resolveRuleset(ctx, packageId, apiType)
+		if err != nil {
+			return nil, err
+		}
+		rulesets[apiType] = rs
+	}
+
+	summaryDocs := make([]view.ValidationDocument, len(docs))
+	results := make([]*view.DocumentResult, len(docs))
+
+	// linters are limited by own semaphores, so it's safe to start all the documents at once
+	wg := sync.WaitGroup{}
+	for i := range docs {
+		summaryDocs[i] = view.ValidationDocument{
+			Status:       view.StatusSuccess,
+			Slug:         slugs[i],
+			ApiType:      apiTypes[i],
+			DocumentName: docs[i].FileName,
+		}
+		if apiTypes[i] == "" {
+			summaryDocs[i].Status = view.StatusError
+			summaryDocs[i].Details = "unable to detect API type of the document"
+			continue
+		}
+		rs := rulesets[apiTypes[i]]
+		if rs == nil {
+			// lint of this type is not supported now
+			summaryDocs[i].Status = view.StatusError
+			summaryDocs[i].Details = fmt.Sprintf("no linter is available for API type %s", apiTypes[i])
+			continue
+		}
+		summaryDocs[i].RulesetId = rs.ruleset.Id
+
+		idx := i
+		wg.Add(1)
+		utils.SafeAsync(func() {
+			defer wg.Done()
+			result, summary, err := d.lintDocument(ctx, docs[idx], apiTypes[idx], slugs[idx], rs)
+			if err != nil {
+				summaryDocs[idx].Status = view.StatusError
+				summaryDocs[idx].Details = err.Error()
+				return
+			}
+			summaryDocs[idx].IssuesSummary = summary
+			results[idx] = result
+		})
+	}
+	wg.Wait()
+
+	summary := view.ValidationSummaryForVersion{
+		Status:    view.VersionStatusSuccess,
+		Documents: summaryDocs,
+	}
+	failedCount := 0
+	for _, doc := range summaryDocs {
+		if doc.Status == view.StatusError {
+			failedCount++
+		}
+	}
+	if failedCount > 0 {
+		summary.Status = view.VersionStatusError
+		summary.Details = fmt.Sprintf("%d document(s) failed", failedCount)
+	}
+	for _, rs := range rulesets {
+		if rs != nil {
+			summary.Rulesets = append(summary.Rulesets, entity.MakeRulesetView(rs.ruleset.Ruleset))
+		}
+	}
+	documents := make([]view.DocumentResult, 0)
+	for _, result := range results {
+		if result != nil {
+			documents = append(documents, *result)
+		}
+	}
+
+	now := time.Now()
+	ent := entity.DraftLintReport{
+		Id:        uuid.NewString(),
+		PackageId: packageId,
+		CreatedBy: secctx.GetUserId(ctx),
+		CreatedAt: now,
+		ExpiresAt: now.Add(d.reportTtl),
+		Summary:   summary,
+		Documents: documents,
+	}
+	err := d.draftRepository.SaveDraftReport(ctx, ent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save draft lint report: %w", err)
+	}
+	log.Infof("Draft lint for package %s (draft id = %s) finished, %d document(s) linted. Processing time = %dms", packageId, ent.Id, len(docs), time.Since(start).Milliseconds())
+
+	result := entity.MakeDraftValidationSummaryView(ent)
+	return &result, nil
+}
+
+func (d draftValidationServiceImpl) GetDraftSummary(ctx context.Context, packageId string, draftId string) (*view.DraftValidationSummary, error) {
+	ent, err := d.getDraftReport(ctx, packageId, draftId)
+	if err != nil {
+		return nil, err
+	}
+	result := entity.MakeDraftValidationSummaryView(*ent)
+	return &result, nil
+}
+
+func (d draftValidationServiceImpl) GetDraftDocumentResult(ctx context.Context, packageId string, draftId string, slug string) (*view.DocumentResult, error) {
+	ent, err := d.getDraftReport(ctx, packageId, draftId)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range ent.Documents {
+		if doc.ValidatedDocument.Slug == slug {
+			return &doc, nil
+		}
+	}
+	return nil, &exception.CustomError{
+		Status:  http.StatusNotFound,
+		Code:    exception.EntityNotFound,
+		Message: exception.EntityNotFoundMsg,
+		Params:  map[string]interface{}{"entity": "draft document", "id": slug},
+	}
+}
+
+func (d draftValidationServiceImpl) getDraftReport(ctx context.Context, packageId string, draftId string) (*entity.DraftLintReport, error) {
+	ent, err := d.draftRepository.GetDraftReport(ctx, draftId)
+	if err != nil {
+		return nil, err
+	}
+	// the report is available only in the context of the package it was made for
+	if ent == nil || ent.PackageId != packageId {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "draft lint report", "id": draftId},
+		}
+	}
+	return ent, nil
+}
+
+// resolveRuleset returns nil if the api type is not supported
+func (d draftValidationServiceImpl) resolveRuleset(ctx context.Context, packageId string, apiType view.ApiType) (*draftRuleset, error) {
+	linterName, rulesetId, err := d.linterSelectorService.SelectLinterAndRuleset(ctx, packageId, apiType)
+	if err != nil {
+		return nil, err
+	}
+	if linterName == view.UnknownLinter {
+		return nil, nil
+	}
+	linter, err := d.linterRegistry.GetLinter(linterName)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := d.rulesetRepository.GetRulesetWithData(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, fmt.Errorf("ruleset with id %s not found", rulesetId)
+	}
+	return &draftRuleset{linter: linter, ruleset: *rs}, nil
+}
+
+func (d draftValidationServiceImpl) lintDocument(ctx context.Context, doc view.DraftDocument, apiType view.ApiType, slug string, rs *draftRuleset) (*view.DocumentResult, *view.IssuesSummary, error) {
+	issues, summary, err := lintDocumentData(ctx, d.rulesetMaterializer, rs.linter, d.lintIgnoreExtension, doc.FileName, doc.Data, rs.ruleset)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.ruleCatalogService.AddRuleDescriptions(ctx, rs.ruleset.Id, issues)
+	return &view.DocumentResult{
+		Ruleset: entity.MakeRulesetView(rs.ruleset.Ruleset),
+		Issues:  issues,
+		ValidatedDocument: view.ValidatedDocument{
+			Slug:    slug,
+			ApiType: apiType,
+			DocName: doc.FileName,
+		},
+	}, summary, nil
+}
+
+func (d draftValidationServiceImpl) cleanupExpiredReports() {
+	t := time.NewTicker(time.Minute * 10)
+	for range t.C {
+		deleted, err := d.draftRepository.DeleteExpiredDraftReports(context.Background())
+		if err != nil {
+			log.Errorf("Failed to delete expired draft lint reports: %s", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Debugf("%d expired draft lint report(s) deleted", deleted)
+		}
+	}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// makeDraftSlugs makes unique slugs from the document file names
+func makeDraftSlugs(docs []view.DraftDocument) []string {
+	slugs := make([]string, len(docs))
+	used := make(map[string]bool)
+	for i, doc := range docs {
+		base := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(doc.FileName), "-"), "-")
+		if base == "" {
+			base = "document"
+		}
+		slug := base
+		for n := 1; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[slug] = true
+		slugs[i] = slug
+	}
+	return slugs
+}
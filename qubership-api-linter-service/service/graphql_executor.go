@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+// graphql-schema-linter reads the configuration only from the file with the fixed name in the config directory
+const graphqlLinterConfigFileName = ".graphql-schema-linterrc"
+
+func NewGraphqlExecutor(graphqlLinterBinPath string, concurrency int) (LinterExecutor, error) {
+	graphqlLinterVersion, err := detectGraphqlLinterVersion(graphqlLinterBinPath)
+	if err != nil {
+		return nil, err
+	}
+	return &graphqlExecutorImpl{graphqlLinterBinPath: graphqlLinterBinPath, semaphore: utils.NewSemaphore(concurrency), graphqlLinterVersion: graphqlLinterVersion}, nil
+}
+
+type graphqlExecutorImpl struct {
+	graphqlLinterBinPath string
+	semaphore            *utils.Semaphore
+	graphqlLinterVersion string
+}
+
+func (g *graphqlExecutorImpl) GetLinter() view.Linter {
+	return view.GraphqlLinter
+}
+
+func (g *graphqlExecutorImpl) GetLinterVersion() string {
+	return g.graphqlLinterVersion
+}
+
+func (g *graphqlExecutorImpl) SupportsApiType(apiType view.ApiType) bool {
+	return apiType == view.GraphQLType
+}
+
+func (g *graphqlExecutorImpl) LintLocalDoc(docPath string, rulesetPath string) ([]byte, int64, error) {
+	g.semaphore.Acquire()
+	defer g.semaphore.Release()
+
+	rulesetData, err := os.ReadFile(rulesetPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading ruleset file: %s", err)
+	}
+	configDir := filepath.Join(filepath.Dir(rulesetPath), "graphql-config")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return nil, 0, fmt.Errorf("error creating config directory: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, graphqlLinterConfigFileName), rulesetData, 0600); err != nil {
+		return nil, 0, fmt.Errorf("error writing config file: %s", err)
+	}
+
+	var args []string
+	args = append(args, "--format")
+	args = append(args, "json")
+	args = append(args, "--config-directory")
+	args = append(args, configDir)
+	args = append(args, docPath)
+
+	limit := time.Minute * 10
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(limit))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, g.graphqlLinterBinPath, args...)
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err = cmd.Run()
+	calculationTime := time.Since(start)
+
+	log.Tracef("stderr: %s", stderr.String())
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errStr := fmt.Sprintf("lint time exceeded limit(%v)", limit)
+			if stderr.String() != "" {
+				errStr += " | stderr: " + stderr.String()
+			}
+			return nil, calculationTime.Milliseconds(), errors.New(errStr)
+		}
+		// graphql-schema-linter exits with status 1 if validation contains at least one error,
+		// status 2 means invalid schema or configuration
+		if err.Error() != "exit status 1" {
+			return nil, calculationTime.Milliseconds(), makeLinterRunError("graphql-schema-linter", err, stderr.String())
+		}
+	}
+
+	return out.Bytes(), calculationTime.Milliseconds(), nil
+}
+
+func (g *graphqlExecutorImpl) GetIssues(report []byte) ([]view.ValidationIssue, error) {
+	var graphqlReport view.GraphqlReport
+	err := json.Unmarshal(report, &graphqlReport)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]view.ValidationIssue, 0)
+	for _, item := range graphqlReport.Errors {
+		issue := view.ValidationIssue{
+			Path:     make([]string, 0), // graphql-schema-linter reports only line and column, they are kept in the range
+			Code:     item.Rule,
+			Severity: "error", // graphql-schema-linter has no severities, all issues are errors
+			Message:  item.Message,
+		}
+		if item.Location.Line > 0 {
+			// the location is one-based
+			position := view.IssuePosition{Line: item.Location.Line - 1, Character: max(item.Location.Column-1, 0)}
+			issue.Range = &view.IssueRange{Start: position, End: position}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+func (g *graphqlExecutorImpl) FilterReport(report []byte, keep func(issue view.ValidationIssue) bool) ([]byte, error) {
+	var reportObj map[string]json.RawMessage
+	err := json.Unmarshal(report, &reportObj)
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	err = json.Unmarshal(reportObj["errors"], &items)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := g.GetIssues(report)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]json.RawMessage, 0, len(items))
+	for i, item := range items {
+		if keep(issues[i]) {
+			kept = append(kept, item)
+		}
+	}
+	reportObj["errors"], err = json.Marshal(kept)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(reportObj)
+}
+
+func (g *graphqlExecutorImpl) CalculateSummary(report []byte) (map[string]interface{}, error) {
+	var graphqlReport view.GraphqlReport
+	err := json.Unmarshal(report, &graphqlReport)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling result: %s", err)
+	}
+	summary := view.GraphqlResultSummary{ErrorCount: len(graphqlReport.Errors)}
+
+	sumJson, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling summary: %s", err)
+	}
+	var sumAsMap map[string]interface{}
+	err = json.Unmarshal(sumJson, &sumAsMap)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling summary: %s", err)
+	}
+	return sumAsMap, nil
+}
+
+func (g *graphqlExecutorImpl) ParseSummary(summary map[string]interface{}) (*view.IssuesSummary, error) {
+	return makeIssuesSummary(summary)
+}
+
+func detectGraphqlLinterVersion(graphqlLinterBinPath string) (string, error) {
+	if graphqlLinterBinPath == "" {
+		return "", fmt.Errorf("graphql-schema-linter executor path is not set (GRAPHQL_LINTER_BIN_PATH env)")
+	}
+	cmd := exec.Command(graphqlLinterBinPath, "--version")
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("error getting graphql-schema-linter version: %s", err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// LintService lints documents synchronously without creating version/doc tasks
+type LintService interface {
+	LintDocument(ctx context.Context, req view.AdHocLintRequest) (*view.DocumentResult, error)
+}
+
+func NewLintService(rulesetRepository repository.RulesetRepository, linterSelectorService LinterSelectorService, linterRegistry LinterRegistry,
+	rulesetMaterializer RulesetMaterializer, ruleCatalogService RuleCatalogService, lintIgnoreExtension string) LintService {
+	return &lintServiceImpl{
+		rulesetRepository:     rulesetRepository,
+		linterSelectorService: linterSelectorService,
+		linterRegistry:        linterRegistry,
+		rulesetMaterializer:   rulesetMaterializer,
+		ruleCatalogService:    ruleCatalogService,
+		lintIgnoreExtension:   lintIgnoreExtension,
+	}
+}
+
+type lintServiceImpl struct {
+	rulesetRepository     repository.RulesetRepository
+	linterSelectorService LinterSelectorService
+	linterRegistry        LinterRegistry
+	rulesetMaterializer   RulesetMaterializer
+	ruleCatalogService    RuleCatalogService
+	lintIgnoreExtension   string
+}
+
+func (l lintServiceImpl) LintDocument(ctx context.Context, req view.AdHocLintRequest) (*view.DocumentResult, error) {
+	apiType := req.ApiType
+	if apiType == "" {
+		apiType = detectApiType(req.FileName, req.Data)
+		if apiType == "" {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.UnknownDocumentApiType,
+				Message: exception.UnknownDocumentApiTypeMsg,
+				Params:  map[string]interface{}{"name": req.FileName},
+			}
+		}
+	}
+
+	linter, ruleset, err := l.resolveRuleset(ctx, apiType, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, lintTimeMs, err := lintInTempDir(ctx, l.rulesetMaterializer, linter, req.FileName, req.Data, *ruleset)
+	if err != nil {
+		// only the linter decision is a client error, other failures are internal
+		var rejectedErr *LinterRejectedError
+		if errors.As(err, &rejectedErr) {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.DocumentLintFailed,
+				Message: exception.DocumentLintFailedMsg,
+				Params:  map[string]interface{}{"name": req.FileName, "error": rejectedErr.Error()},
+			}
+		}
+		if _, ok := err.(*exception.CustomError); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to lint document %s: %w", req.FileName, err)
+	}
+	log.Debugf("Ad-hoc lint of document %s by %s took %dms", req.FileName, linter.GetLinter(), lintTimeMs)
+
+	report, _, err = suppressIgnoredIssues(linter, l.lintIgnoreExtension, req.Data, report)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := linter.GetIssues(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s report: %w", linter.GetLinter(), err)
+	}
+	// inline rulesets are not stored, so they have no rule catalog
+	l.ruleCatalogService.AddRuleDescriptions(ctx, ruleset.Id, issues)
+
+	return &view.DocumentResult{
+		Ruleset: entity.MakeRulesetView(ruleset.Ruleset),
+		Issues:  issues,
+		ValidatedDocument: view.ValidatedDocument{
+			ApiType: apiType,
+			DocName: req.FileName,
+		},
+	}, nil
+}
+
+// resolveRuleset returns the linter and the ruleset requested explicitly or the active one for the api type
+func (l lintServiceImpl) resolveRuleset(ctx context.Context, apiType view.ApiType, req view.AdHocLintRequest) (LinterExecutor, *entity.RulesetWithData, error) {
+	if req.Ruleset != nil {
+		linterName := req.Ruleset.Linter
+		if linterName == "" {
+			linters := l.linterRegistry.GetLintersForApiType(apiType)
+			if len(linters) == 0 {
+				return nil, nil, makeNoLinterError(req.FileName, apiType)
+			}
+			linterName = linters[0].GetLinter()
+		}
+		linter, err := l.getLinterForApiType(linterName, apiType)
+		if err != nil {
+			return nil, nil, err
+		}
+		ruleset := &entity.RulesetWithData{
+			Ruleset: entity.Ruleset{
+				Name:     "inline",
+				FileName: req.Ruleset.FileName,
+				Linter:   linterName,
+				ApiType:  apiType,
+			},
+			Data: req.Ruleset.Data,
+		}
+		return linter, ruleset, nil
+	}
+
+	rulesetId := req.RulesetId
+	if rulesetId == "" {
+		linterName, activeRulesetId, err := l.linterSelectorService.SelectLinterAndRuleset(ctx, "", apiType)
+		if err != nil {
+			return nil, nil, err
+		}
+		if linterName == view.UnknownLinter {
+			return nil, nil, makeNoLinterError(req.FileName, apiType)
+		}
+		rulesetId = activeRulesetId
+	}
+
+	rs, err := l.rulesetRepository.GetRulesetWithData(ctx, rulesetId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rs == nil {
+		return nil, nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+	if rs.ApiType != apiType {
+		return nil, nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetApiTypeMismatch,
+			Message: exception.RulesetApiTypeMismatchMsg,
+			Params:  map[string]interface{}{"id": rulesetId, "rulesetType": rs.ApiType, "type": apiType},
+		}
+	}
+	linter, err := l.getLinterForApiType(rs.Linter, apiType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return linter, rs, nil
+}
+
+func (l lintServiceImpl) getLinterForApiType(linterName view.Linter, apiType view.ApiType) (LinterExecutor, error) {
+	linter, err := l.linterRegistry.GetLinter(linterName)
+	if err != nil || !linter.SupportsApiType(apiType) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.LinterNotSupportedForApiType,
+			Message: exception.LinterNotSupportedForApiTypeMsg,
+			Params:  map[string]interface{}{"linter": linterName, "type": apiType},
+		}
+	}
+	return linter, nil
+}
+
+func makeNoLinterError(fileName string, apiType view.ApiType) error {
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.DocumentLintFailed,
+		Message: exception.DocumentLintFailedMsg,
+		Params:  map[string]interface{}{"name": fileName, "error": fmt.Sprintf("no linter is available for API type %s", apiType)},
+	}
+}
+
+// lintInTempDir writes the document and the ruleset to a temporary directory and lints the document there
+func lintInTempDir(ctx context.Context, rulesetMaterializer RulesetMaterializer, linter LinterExecutor, docFileName string, docData []byte,
+	ruleset entity.RulesetWithData) ([]byte, int64, error) {
+	tempDir := filepath.Join(os.TempDir(), uuid.NewString())
+	if err := os.MkdirAll(tempDir, 0700); err != nil {
+		return nil, 0, fmt.Errorf("error creating temp directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Some linters (e.g. Spectral) have a problem with some characters is file names, so generating safe ones.
+	docPath := filepath.Join(tempDir, "file"+filepath.Ext(docFileName))
+	if err := os.WriteFile(docPath, docData, 0600); err != nil {
+		return nil, 0, fmt.Errorf("error writing doc file: %s", err)
+	}
+	rulesetPath, err := rulesetMaterializer.Materialize(ctx, ruleset, tempDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return linter.LintLocalDoc(docPath, rulesetPath)
+}
+
+// lintDocumentData lints the document in a temporary directory and returns issues with their summary,
+// issues suppressed by the inline extension are excluded the same way as for published versions
+func lintDocumentData(ctx context.Context, rulesetMaterializer RulesetMaterializer, linter LinterExecutor, lintIgnoreExtension string,
+	docFileName string, docData []byte, ruleset entity.RulesetWithData) ([]view.ValidationIssue, *view.IssuesSummary, error) {
+	report, _, err := lintInTempDir(ctx, rulesetMaterializer, linter, docFileName, docData, ruleset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error linting doc with %s: %s", linter.GetLinter(), err)
+	}
+	report, _, err = suppressIgnoredIssues(linter, lintIgnoreExtension, docData, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, err := linter.GetIssues(report)
+	if err != nil {
+		return nil, nil, err
+	}
+	sumAsMap, err := linter.CalculateSummary(report)
+	if err != nil {
+		return nil, nil, err
+	}
+	summary, err := linter.ParseSummary(sumAsMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, summary, nil
+}
+
+// detectApiType detects the api type by the document content, returns empty string if the type is unknown
+func detectApiType(fileName string, data []byte) view.ApiType {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == ".graphql" || ext == ".gql" || ext == ".graphqls" {
+		return view.GraphQLType
+	}
+
+	var header struct {
+		OpenAPI  string `yaml:"openapi"`
+		Swagger  string `yaml:"swagger"`
+		AsyncAPI string `yaml:"asyncapi"`
+	}
+	// JSON is a subset of YAML, so both formats are supported
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(header.OpenAPI, "3.1"):
+		return view.OpenAPI31Type
+	case strings.HasPrefix(header.OpenAPI, "3.0"):
+		return view.OpenAPI30Type
+	case strings.HasPrefix(header.Swagger, "2."):
+		return view.OpenAPI20Type
+	case strings.HasPrefix(header.AsyncAPI, "2."):
+		return view.AsyncAPI2Type
+	case strings.HasPrefix(header.AsyncAPI, "3."):
+		return view.AsyncAPI3Type
+	}
+	return ""
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+// LintDiffService compares lint issues of a version with the issues of the previous version
+type LintDiffService interface {
+	// BuildVersionDiff computes and stores the diff of the completed version lint with the previous version,
+	// nil is returned if the previous version is not linted
+	BuildVersionDiff(ctx context.Context, ver entity.LintedVersion) (*entity.VersionLintDiff, error)
+	// GetVersionDiff compares the version with the specified version or with the previous version if previousVersion is empty
+	GetVersionDiff(ctx context.Context, packageId string, version string, previousPackageId string, previousVersion string) (*view.VersionLintDiff, error)
+}
+
+func NewLintDiffService(lintDiffRepository repository.LintDiffRepository, versionResultRepository repository.VersionResultRepository,
+	lintResultRepository repository.LintResultRepository, rulesetRepository repository.RulesetRepository,
+	linterRegistry LinterRegistry, ruleCatalogService RuleCatalogService, apihubClient client.ApihubClient) LintDiffService {
+	return &lintDiffServiceImpl{
+		lintDiffRepository:      lintDiffRepository,
+		versionResultRepository: versionResultRepository,
+		lintResultRepository:    lintResultRepository,
+		rulesetRepository:       rulesetRepository,
+		linterRegistry:          linterRegistry,
+		ruleCatalogService:      ruleCatalogService,
+		apihubClient:            apihubClient,
+	}
+}
+
+type lintDiffServiceImpl struct {
+	lintDiffRepository      repository.LintDiffRepository
+	versionResultRepository repository.VersionResultRepository
+	lintResultRepository    repository.LintResultRepository
+	rulesetRepository       repository.RulesetRepository
+	linterRegistry          LinterRegistry
+	ruleCatalogService      RuleCatalogService
+	apihubClient            client.ApihubClient
+}
+
+type lintedVersionWithDocs struct {
+	version entity.LintedVersion
+	docs    []entity.LintedDocument
+}
+
+func (l lintDiffServiceImpl) BuildVersionDiff(ctx context.Context, ver entity.LintedVersion) (*entity.VersionLintDiff, error) {
+	// the version is not saved as completed yet, so only the documents are taken from the db
+	_, docs, err := l.versionResultRepository.GetVersionAndDocsSummary(ctx, ver.PackageId, ver.Version, ver.Revision)
+	if err != nil {
+		return nil, err
+	}
+	current := lintedVersionWithDocs{version: ver, docs: docs}
+	prevPackageId, prevVersion, prevRevision, err := l.getPreviousVersion(ctx, ver.PackageId, ver.Version, ver.Revision)
+	if err != nil {
+		return nil, err
+	}
+	if prevVersion == "" {
+		return nil, nil
+	}
+	previous, err := l.getLintedVersion(ctx, prevPackageId, prevVersion, prevRevision)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		log.Debugf("Lint diff for %s@%d of package %s is not built, previous version %s@%d of package %s is not linted",
+			ver.Version, ver.Revision, ver.PackageId, prevVersion, prevRevision, prevPackageId)
+		return nil, nil
+	}
+	return l.buildAndSave(ctx, current, *previous)
+}
+
+func (l lintDiffServiceImpl) GetVersionDiff(ctx context.Context, packageId string, version string, previousPackageId string, previousVersion string) (*view.VersionLintDiff, error) {
+	ver, rev, err := resolveVersionRevision(ctx, l.apihubClient, packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	current, err := l.getLintedVersion(ctx, packageId, ver, rev)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.LintResultNotFound,
+			Message: exception.LintResultNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "version": version},
+		}
+	}
+
+	explicitPrevious := previousVersion != ""
+	var prevVer string
+	var prevRev int
+	if explicitPrevious {
+		if previousPackageId == "" {
+			previousPackageId = packageId
+		}
+		prevVer, prevRev, err = resolveVersionRevision(ctx, l.apihubClient, previousPackageId, previousVersion)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		stored, err := l.lintDiffRepository.GetVersionDiff(ctx, packageId, ver, rev)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil && stored.LintedAt.Equal(current.version.LintedAt) {
+			prevLinted, err := l.versionResultRepository.GetLintedVersion(ctx, stored.PreviousPackageId, stored.PreviousVersion, stored.PreviousRevision)
+			if err != nil {
+				return nil, err
+			}
+			if prevLinted != nil && stored.PreviousLintedAt.Equal(prevLinted.LintedAt) {
+				result := entity.MakeVersionLintDiffView(*stored)
+				return &result, nil
+			}
+		}
+		previousPackageId, prevVer, prevRev, err = l.getPreviousVersion(ctx, packageId, ver, rev)
+		if err != nil {
+			return nil, err
+		}
+		if prevVer == "" {
+			return nil, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.PreviousVersionNotFound,
+				Message: exception.PreviousVersionNotFoundMsg,
+				Params:  map[string]interface{}{"packageId": packageId, "version": version},
+			}
+		}
+	}
+
+	previous, err := l.getLintedVersion(ctx, previousPackageId, prevVer, prevRev)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.LintResultNotFound,
+			Message: exception.LintResultNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": previousPackageId, "version": fmt.Sprintf("%s@%d", prevVer, prevRev)},
+		}
+	}
+
+	var ent *entity.VersionLintDiff
+	if explicitPrevious {
+		ent, err = l.buildDiff(ctx, *current, *previous)
+	} else {
+		ent, err = l.buildAndSave(ctx, *current, *previous)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := entity.MakeVersionLintDiffView(*ent)
+	return &result, nil
+}
+
+func (l lintDiffServiceImpl) buildAndSave(ctx context.Context, current lintedVersionWithDocs, previous lintedVersionWithDocs) (*entity.VersionLintDiff, error) {
+	ent, err := l.buildDiff(ctx, current, previous)
+	if err != nil {
+		return nil, err
+	}
+	err = l.lintDiffRepository.SaveVersionDiff(ctx, *ent)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Lint diff for %s@%d of package %s with %s@%d of package %s is saved",
+		ent.Version, ent.Revision, ent.PackageId, ent.PreviousVersion, ent.PreviousRevision, ent.PreviousPackageId)
+	return ent, nil
+}
+
+// getPreviousVersion returns the previous version with the latest revision, version is empty if there is no previous version
+func (l lintDiffServiceImpl) getPreviousVersion(ctx context.Context, packageId string, version string, revision int) (string, string, int, error) {
+	versionContent, err := l.apihubClient.GetVersion(ctx, packageId, fmt.Sprintf("%s@%d", version, revision))
+	if err != nil {
+		return "", "", 0, err
+	}
+	if versionContent == nil || versionContent.PreviousVersion == "" {
+		return "", "", 0, nil
+	}
+	prevPackageId := versionContent.PreviousVersionPackageId
+	if prevPackageId == "" {
+		prevPackageId = packageId
+	}
+	prevVersion, prevRevision, err := resolveVersionRevision(ctx, l.apihubClient, prevPackageId, versionContent.PreviousVersion)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return prevPackageId, prevVersion, prevRevision, nil
+}
+
+func (l lintDiffServiceImpl) getLintedVersion(ctx context.Context, packageId string, version string, revision int) (*lintedVersionWithDocs, error) {
+	ver, docs, err := l.versionResultRepository.GetVersionAndDocsSummary(ctx, packageId, version, revision)
+	if err != nil {
+		return nil, err
+	}
+	if ver == nil || ver.LintStatus == view.VersionStatusInProgress {
+		return nil, nil
+	}
+	return &lintedVersionWithDocs{version: *ver, docs: docs}, nil
+}
+
+func (l lintDiffServiceImpl) buildDiff(ctx context.Context, current lintedVersionWithDocs, previous lintedVersionWithDocs) (*entity.VersionLintDiff, error) {
+	result := entity.VersionLintDiff{
+		PackageId:         current.version.PackageId,
+		Version:           current.version.Version,
+		Revision:          current.version.Revision,
+		PreviousPackageId: previous.version.PackageId,
+		PreviousVersion:   previous.version.Version,
+		PreviousRevision:  previous.version.Revision,
+		LintedAt:          current.version.LintedAt,
+		PreviousLintedAt:  previous.version.LintedAt,
+		CreatedAt:         time.Now(),
+		Documents:         make([]view.DocumentLintDiff, 0),
+	}
+	rulesets := make(map[string]*entity.Ruleset)
+
+	previousDocs := make(map[string]entity.LintedDocument, len(previous.docs))
+	for _, doc := range previous.docs {
+		previousDocs[doc.Slug] = doc
+	}
+	for _, doc := range current.docs {
+		docDiff := view.DocumentLintDiff{
+			Slug:         doc.Slug,
+			DocumentName: doc.FileId,
+			ApiType:      doc.SpecificationType,
+			RulesetId:    doc.RulesetId,
+		}
+		prevDoc, exists := previousDocs[doc.Slug]
+		delete(previousDocs, doc.Slug)
+
+		currentIssues, err := l.getDocumentIssues(ctx, doc, rulesets)
+		if err != nil {
+			docDiff.Status = view.DocumentNotCompared
+			docDiff.Details = err.Error()
+			result.Documents = append(result.Documents, docDiff)
+			continue
+		}
+		if !exists {
+			docDiff.Status = view.DocumentAdded
+			addDocumentDiffIssues(&docDiff, currentIssues, nil)
+			result.Documents = append(result.Documents, docDiff)
+			continue
+		}
+		docDiff.PreviousRulesetId = prevDoc.RulesetId
+		if prevDoc.DataHash == doc.DataHash && prevDoc.RulesetId == doc.RulesetId && prevDoc.LintStatus == doc.LintStatus {
+			// the same lint result, so all the issues are unchanged
+			docDiff.Status = view.DocumentUnchanged
+			addDocumentDiffIssues(&docDiff, nil, nil)
+			for _, issue := range currentIssues {
+				docDiff.Summary.Unchanged.AddIssue(issue.Severity)
+			}
+			result.Documents = append(result.Documents, docDiff)
+			continue
+		}
+		previousIssues, err := l.getDocumentIssues(ctx, prevDoc, rulesets)
+		if err != nil {
+			docDiff.Status = view.DocumentNotCompared
+			docDiff.Details = fmt.Sprintf("previous version: %s", err)
+			result.Documents = append(result.Documents, docDiff)
+			continue
+		}
+		docDiff.Status = view.DocumentChanged
+		addDocumentDiffIssues(&docDiff, currentIssues, previousIssues)
+		result.Documents = append(result.Documents, docDiff)
+	}
+
+	removedSlugs := make([]string, 0, len(previousDocs))
+	for slug := range previousDocs {
+		removedSlugs = append(removedSlugs, slug)
+	}
+	sort.Strings(removedSlugs)
+	for _, slug := range removedSlugs {
+		prevDoc := previousDocs[slug]
+		docDiff := view.DocumentLintDiff{
+			Slug:              prevDoc.Slug,
+			DocumentName:      prevDoc.FileId,
+			ApiType:           prevDoc.SpecificationType,
+			Status:            view.DocumentRemoved,
+			PreviousRulesetId: prevDoc.RulesetId,
+		}
+		previousIssues, err := l.getDocumentIssues(ctx, prevDoc, rulesets)
+		if err != nil {
+			docDiff.Details = fmt.Sprintf("previous version: %s", err)
+		}
+		addDocumentDiffIssues(&docDiff, nil, previousIssues)
+		result.Documents = append(result.Documents, docDiff)
+	}
+
+	for i := range result.Documents {
+		docDiff := &result.Documents[i]
+		if docDiff.Introduced == nil {
+			docDiff.Introduced = make([]view.ValidationIssue, 0)
+		}
+		if docDiff.Fixed == nil {
+			docDiff.Fixed = make([]view.ValidationIssue, 0)
+		}
+		l.ruleCatalogService.AddRuleDescriptions(ctx, docDiff.RulesetId, docDiff.Introduced)
+		l.ruleCatalogService.AddRuleDescriptions(ctx, docDiff.PreviousRulesetId, docDiff.Fixed)
+		addIssuesSummary(&result.Summary.Introduced, docDiff.Summary.Introduced)
+		addIssuesSummary(&result.Summary.Fixed, docDiff.Summary.Fixed)
+		addIssuesSummary(&result.Summary.Unchanged, docDiff.Summary.Unchanged)
+	}
+	return &result, nil
+}
+
+func (l lintDiffServiceImpl) getDocumentIssues(ctx context.Context, doc entity.LintedDocument, rulesets map[string]*entity.Ruleset) ([]view.ValidationIssue, error) {
+	if doc.LintStatus == view.StatusError {
+		return nil, fmt.Errorf("document lint failed: %s", doc.LintDetails)
+	}
+	ruleset, cached := rulesets[doc.RulesetId]
+	if !cached {
+		var err error
+		ruleset, err = l.rulesetRepository.GetRulesetById(ctx, doc.RulesetId)
+		if err != nil {
+			return nil, err
+		}
+		rulesets[doc.RulesetId] = ruleset
+	}
+	if ruleset == nil {
+		return nil, fmt.Errorf("ruleset with id %s not found", doc.RulesetId)
+	}
+	lintResult, err := l.lintResultRepository.GetLintResult(ctx, doc.DataHash, doc.RulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if lintResult == nil {
+		return nil, fmt.Errorf("lint result not found")
+	}
+	linter, err := l.linterRegistry.GetLinter(ruleset.Linter)
+	if err != nil {
+		return nil, err
+	}
+	return linter.GetIssues(lintResult.Data)
+}
+
+// addDocumentDiffIssues classifies the issues, issues are matched by rule code and normalized path.
+// Issues with the same key are matched by count, the excessive ones are introduced or fixed.
+func addDocumentDiffIssues(docDiff *view.DocumentLintDiff, currentIssues []view.ValidationIssue, previousIssues []view.ValidationIssue) {
+	docDiff.Introduced = make([]view.ValidationIssue, 0)
+	docDiff.Fixed = make([]view.ValidationIssue, 0)
+
+	currentCounts := make(map[string]int)
+	for _, issue := range currentIssues {
+		currentCounts[makeIssueKey(issue)]++
+	}
+	previousCounts := make(map[string]int)
+	for _, issue := range previousIssues {
+		previousCounts[makeIssueKey(issue)]++
+	}
+
+	matched := make(map[string]int)
+	for _, issue := range currentIssues {
+		key := makeIssueKey(issue)
+		if matched[key] < previousCounts[key] {
+			matched[key]++
+			docDiff.Summary.Unchanged.AddIssue(issue.Severity)
+			continue
+		}
+		docDiff.Introduced = append(docDiff.Introduced, issue)
+		docDiff.Summary.Introduced.AddIssue(issue.Severity)
+	}
+	matched = make(map[string]int)
+	for _, issue := range previousIssues {
+		key := makeIssueKey(issue)
+		if matched[key] < currentCounts[key] {
+			matched[key]++
+			continue
+		}
+		docDiff.Fixed = append(docDiff.Fixed, issue)
+		docDiff.Summary.Fixed.AddIssue(issue.Severity)
+	}
+}
+
+func makeIssueKey(issue view.ValidationIssue) string {
+	return issue.Code + " " + normalizeIssuePath(issue.Path)
+}
+
+// normalizeIssuePath makes JSON pointer from the path. Segments are kept literal since a numeric segment is not
+// necessarily an array index (e.g. response codes), so issues of shifted array items are reported as introduced and fixed.
+func normalizeIssuePath(path []string) string {
+	return utils.MakeJsonPointer(path)
+}
+
+func addIssuesSummary(summary *view.IssuesSummary, add view.IssuesSummary) {
+	summary.Error += add.Error
+	summary.Warning += add.Warning
+	summary.Info += add.Info
+	summary.Hint += add.Hint
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+func TestNormalizeIssuePath(t *testing.T) {
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"info", "title"}, "/info/title"},
+		{[]string{"paths", "/items/{id}", "get"}, "/paths/~1items~1{id}/get"},
+		{[]string{"paths", "/items", "get", "responses", "200"}, "/paths/~1items/get/responses/200"},
+		{[]string{"tags", "0", "name"}, "/tags/0/name"},
+		{[]string{"components", "schemas", "a~b"}, "/components/schemas/a~0b"},
+	}
+	for _, test := range tests {
+		if actual := normalizeIssuePath(test.path); actual != test.expected {
+			t.Errorf("normalizeIssuePath(%q) = %q, expected %q", test.path, actual, test.expected)
+		}
+	}
+}
+
+func TestAddDocumentDiffIssues(t *testing.T) {
+	issue := func(code string, severity string, path ...string) view.ValidationIssue {
+		return view.ValidationIssue{Code: code, Severity: severity, Path: path}
+	}
+	tests := []struct {
+		name       string
+		current    []view.ValidationIssue
+		previous   []view.ValidationIssue
+		introduced []string
+		fixed      []string
+		summary    view.LintDiffSummary
+	}{
+		{
+			name:     "unchanged",
+			current:  []view.ValidationIssue{issue("info-contact", "warning", "info")},
+			previous: []view.ValidationIssue{issue("info-contact", "warning", "info")},
+			summary:  view.LintDiffSummary{Unchanged: view.IssuesSummary{Warning: 1}},
+		},
+		{
+			name:       "different response codes are not matched",
+			current:    []view.ValidationIssue{issue("response-description", "error", "paths", "/items", "get", "responses", "500")},
+			previous:   []view.ValidationIssue{issue("response-description", "error", "paths", "/items", "get", "responses", "200")},
+			introduced: []string{"/paths/~1items/get/responses/500"},
+			fixed:      []string{"/paths/~1items/get/responses/200"},
+			summary:    view.LintDiffSummary{Introduced: view.IssuesSummary{Error: 1}, Fixed: view.IssuesSummary{Error: 1}},
+		},
+		{
+			name:       "different rules on the same path",
+			current:    []view.ValidationIssue{issue("tag-description", "warning", "tags", "0")},
+			previous:   []view.ValidationIssue{issue("tag-name", "warning", "tags", "0")},
+			introduced: []string{"/tags/0"},
+			fixed:      []string{"/tags/0"},
+			summary:    view.LintDiffSummary{Introduced: view.IssuesSummary{Warning: 1}, Fixed: view.IssuesSummary{Warning: 1}},
+		},
+		{
+			name: "excessive issues with the same key",
+			current: []view.ValidationIssue{
+				issue("no-$ref-siblings", "error", "components", "schemas", "Item"),
+				issue("no-$ref-siblings", "error", "components", "schemas", "Item"),
+				issue("no-$ref-siblings", "error", "components", "schemas", "Item"),
+			},
+			previous:   []view.ValidationIssue{issue("no-$ref-siblings", "error", "components", "schemas", "Item")},
+			introduced: []string{"/components/schemas/Item", "/components/schemas/Item"},
+			summary:    view.LintDiffSummary{Introduced: view.IssuesSummary{Error: 2}, Unchanged: view.IssuesSummary{Error: 1}},
+		},
+		{
+			name:       "added document",
+			current:    []view.ValidationIssue{issue("info-contact", "info", "info"), issue("oas3-schema", "hint", "paths")},
+			introduced: []string{"/info", "/paths"},
+			summary:    view.LintDiffSummary{Introduced: view.IssuesSummary{Info: 1, Hint: 1}},
+		},
+		{
+			name:     "removed document",
+			previous: []view.ValidationIssue{issue("info-contact", "warning", "info")},
+			fixed:    []string{"/info"},
+			summary:  view.LintDiffSummary{Fixed: view.IssuesSummary{Warning: 1}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			docDiff := view.DocumentLintDiff{}
+			addDocumentDiffIssues(&docDiff, test.current, test.previous)
+			assertIssuePaths(t, "introduced", test.introduced, docDiff.Introduced)
+			assertIssuePaths(t, "fixed", test.fixed, docDiff.Fixed)
+			if docDiff.Summary != test.summary {
+				t.Errorf("expected summary %+v, got %+v", test.summary, docDiff.Summary)
+			}
+		})
+	}
+}
+
+func assertIssuePaths(t *testing.T, kind string, expected []string, issues []view.ValidationIssue) {
+	t.Helper()
+	if issues == nil {
+		t.Errorf("%s issues are nil", kind)
+	}
+	if len(expected) != len(issues) {
+		t.Errorf("expected %d %s issues, got %d", len(expected), kind, len(issues))
+		return
+	}
+	for i, issue := range issues {
+		if actual := normalizeIssuePath(issue.Path); actual != expected[i] {
+			t.Errorf("expected %s issue path %s, got %s", kind, expected[i], actual)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	suppressAllRules = "*"
+	// suppressedIssuesSummaryKey holds suppressed issues count by rule in the lint file result summary
+	suppressedIssuesSummaryKey = "suppressed"
+)
+
+// suppressIgnoredIssues removes issues located under a node which carries the suppression extension with the issue rule code
+// (or "*"), e.g. `x-lint-ignore: [operation-description]`. Returns the filtered report and suppressed issues count by rule.
+func suppressIgnoredIssues(linter LinterExecutor, extensionName string, doc []byte, report []byte) ([]byte, map[string]int, error) {
+	var root interface{}
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		// not a json/yaml document (e.g. graphql schema), nothing to suppress
+		return report, nil, nil
+	}
+	if !hasSuppressionExtension(root, extensionName) {
+		return report, nil, nil
+	}
+
+	suppressed := map[string]int{}
+	filtered, err := linter.FilterReport(report, func(issue view.ValidationIssue) bool {
+		if isIssueSuppressed(root, extensionName, issue) {
+			suppressed[issue.Code] += 1
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to filter suppressed issues: %w", err)
+	}
+	if len(suppressed) == 0 {
+		return report, nil, nil
+	}
+	return filtered, suppressed, nil
+}
+
+func isIssueSuppressed(root interface{}, extensionName string, issue view.ValidationIssue) bool {
+	node := root
+	if suppressesRule(node, extensionName, issue.Code) {
+		return true
+	}
+	for _, segment := range issue.Path {
+		node = getChildNode(node, segment)
+		if node == nil {
+			return false
+		}
+		if suppressesRule(node, extensionName, issue.Code) {
+			return true
+		}
+	}
+	return false
+}
+
+func getChildNode(node interface{}, segment string) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range n {
+			if fmt.Sprint(key) == segment {
+				return value
+			}
+		}
+	case []interface{}:
+		idx, err := strconv.Atoi(segment)
+		if err == nil && idx >= 0 && idx < len(n) {
+			return n[idx]
+		}
+	}
+	return nil
+}
+
+func suppressesRule(node interface{}, extensionName string, code string) bool {
+	obj, ok := node.(map[interface{}]interface{})
+	if !ok {
+		return false
+	}
+	switch ext := obj[extensionName].(type) {
+	case string:
+		return ext == code || ext == suppressAllRules
+	case []interface{}:
+		for _, item := range ext {
+			rule := fmt.Sprint(item)
+			if rule == code || rule == suppressAllRules {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasSuppressionExtension(node interface{}, extensionName string) bool {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		if _, exists := n[extensionName]; exists {
+			return true
+		}
+		for _, value := range n {
+			if hasSuppressionExtension(value, extensionName) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, value := range n {
+			if hasSuppressionExtension(value, extensionName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getSuppressedIssues reads suppressed issues count by rule from the stored lint file result summary
+func getSuppressedIssues(summary map[string]interface{}) map[string]int {
+	suppressedObj, ok := summary[suppressedIssuesSummaryKey].(map[string]interface{})
+	if !ok || len(suppressedObj) == 0 {
+		return nil
+	}
+	result := make(map[string]int, len(suppressedObj))
+	for rule, count := range suppressedObj {
+		if countF, ok := count.(float64); ok {
+			result[rule] = int(countF)
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"gopkg.in/yaml.v2"
+)
+
+func TestIsIssueSuppressed(t *testing.T) {
+	doc := `
+openapi: 3.0.3
+info:
+  title: Sample
+  version: 1.0.0
+paths:
+  /items:
+    x-lint-ignore: operation-description
+    get:
+      responses:
+        "200":
+          description: OK
+  /orders:
+    get:
+      x-lint-ignore: [operation-tags, operation-operationId]
+      responses:
+        "200":
+          description: OK
+  /legacy:
+    x-lint-ignore: "*"
+    get:
+      responses:
+        "200":
+          description: OK
+tags:
+  - name: items
+  - name: orders
+    x-lint-ignore: tag-description
+`
+	var root interface{}
+	if err := yaml.Unmarshal([]byte(doc), &root); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		code     string
+		path     []string
+		expected bool
+	}{
+		{"rule on the node", "operation-description", []string{"paths", "/items"}, true},
+		{"rule below the node", "operation-description", []string{"paths", "/items", "get"}, true},
+		{"another rule", "operation-tags", []string{"paths", "/items", "get"}, false},
+		{"rule in the list", "operation-operationId", []string{"paths", "/orders", "get", "responses", "200"}, true},
+		{"rule not in the list", "operation-description", []string{"paths", "/orders", "get"}, false},
+		{"node above the extension", "operation-tags", []string{"paths", "/orders"}, false},
+		{"all rules", "any-rule", []string{"paths", "/legacy", "get"}, true},
+		{"array item", "tag-description", []string{"tags", "1"}, true},
+		{"another array item", "tag-description", []string{"tags", "0"}, false},
+		{"array index out of range", "tag-description", []string{"tags", "5"}, false},
+		{"missing node", "operation-description", []string{"paths", "/unknown", "get"}, false},
+		{"root", "info-contact", nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issue := view.ValidationIssue{Code: test.code, Path: test.path}
+			if actual := isIssueSuppressed(root, "x-lint-ignore", issue); actual != test.expected {
+				t.Errorf("isIssueSuppressed(%s at %q) = %v, expected %v", test.code, test.path, actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestIsIssueSuppressed_CustomExtension(t *testing.T) {
+	var root interface{}
+	if err := yaml.Unmarshal([]byte("x-company-ignore: info-contact\nx-lint-ignore: info-description\ninfo: {}\n"), &root); err != nil {
+		t.Fatal(err)
+	}
+	if !isIssueSuppressed(root, "x-company-ignore", view.ValidationIssue{Code: "info-contact", Path: []string{"info"}}) {
+		t.Error("expected the issue to be suppressed by the configured extension")
+	}
+	if isIssueSuppressed(root, "x-company-ignore", view.ValidationIssue{Code: "info-description", Path: []string{"info"}}) {
+		t.Error("expected the default extension to be ignored when another one is configured")
+	}
+}
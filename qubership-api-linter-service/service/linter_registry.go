@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+// LinterRejectedError means that the linter process finished with failure status since it rejected the document or the ruleset,
+// unlike timeouts and other execution failures which are not caused by the input
+type LinterRejectedError struct {
+	Details string
+}
+
+func (e *LinterRejectedError) Error() string {
+	return e.Details
+}
+
+// makeLinterRunError makes the error of the linter process run, LinterRejectedError is returned if the process exited by itself
+func makeLinterRunError(linterName string, err error, stderr string) error {
+	errStr := err.Error()
+	if stderr != "" {
+		errStr += " | stderr: " + stderr
+	}
+	msg := fmt.Sprintf("failed to get %s report: %v", linterName, errStr)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &LinterRejectedError{Details: msg}
+	}
+	return errors.New(msg)
+}
+
+// LinterExecutor is implemented by every linter backend supported by the service.
+type LinterExecutor interface {
+	GetLinter() view.Linter
+	GetLinterVersion() string
+	SupportsApiType(apiType view.ApiType) bool
+	// LintLocalDoc lints the document with the ruleset and returns the linter native report and lint time in ms
+	LintLocalDoc(docPath string, rulesetPath string) ([]byte, int64, error)
+	// GetIssues converts the native report into the common issues model
+	GetIssues(report []byte) ([]view.ValidationIssue, error)
+	// FilterReport returns the native report without the issues which are not accepted by keep
+	FilterReport(report []byte, keep func(issue view.ValidationIssue) bool) ([]byte, error)
+	// CalculateSummary makes the summary which is stored along with the native report
+	CalculateSummary(report []byte) (map[string]interface{}, error)
+	// ParseSummary converts the stored summary into the common summary model
+	ParseSummary(summary map[string]interface{}) (*view.IssuesSummary, error)
+}
+
+type LinterRegistry interface {
+	GetLinter(linter view.Linter) (LinterExecutor, error)
+	IsLinterSupported(linter view.Linter) bool
+	// GetLintersForApiType returns linters which are able to lint the api type in the order of registration
+	GetLintersForApiType(apiType view.ApiType) []LinterExecutor
+}
+
+func NewLinterRegistry(executors ...LinterExecutor) LinterRegistry {
+	registry := &linterRegistryImpl{
+		executors: make(map[view.Linter]LinterExecutor),
+	}
+	for _, executor := range executors {
+		if executor == nil {
+			continue
+		}
+		if _, exists := registry.executors[executor.GetLinter()]; exists {
+			continue
+		}
+		registry.executors[executor.GetLinter()] = executor
+		registry.order = append(registry.order, executor.GetLinter())
+	}
+	return registry
+}
+
+type linterRegistryImpl struct {
+	executors map[view.Linter]LinterExecutor
+	order     []view.Linter
+}
+
+func (l linterRegistryImpl) GetLinter(linter view.Linter) (LinterExecutor, error) {
+	executor, exists := l.executors[linter]
+	if !exists {
+		return nil, fmt.Errorf("linter %s is not supported", linter)
+	}
+	return executor, nil
+}
+
+func (l linterRegistryImpl) IsLinterSupported(linter view.Linter) bool {
+	_, exists := l.executors[linter]
+	return exists
+}
+
+func (l linterRegistryImpl) GetLintersForApiType(apiType view.ApiType) []LinterExecutor {
+	var result []LinterExecutor
+	for _, linter := range l.order {
+		executor := l.executors[linter]
+		if executor.SupportsApiType(apiType) {
+			result = append(result, executor)
+		}
+	}
+	return result
+}
+
+// makeIssuesSummary reads the summary stored in errorCount/warningCount/infoCount/hintCount format
+func makeIssuesSummary(summary map[string]interface{}) (*view.IssuesSummary, error) {
+	result := view.IssuesSummary{}
+	errCStr, ok := summary["errorCount"] // summary could be empty
+	if ok {
+		if errC, ok := errCStr.(float64); ok {
+			result.Error = int(errC)
+		}
+	}
+	warningCStr, ok := summary["warningCount"]
+	if ok {
+		if warningC, ok := warningCStr.(float64); ok {
+			result.Warning = int(warningC)
+		}
+	}
+
+	infoCStr, ok := summary["infoCount"]
+	if ok {
+		if infoC, ok := infoCStr.(float64); ok {
+			result.Info = int(infoC)
+		}
+	}
+
+	hintCStr, ok := summary["hintCount"]
+	if ok {
+		if infoC, ok := hintCStr.(float64); ok {
+			result.Hint = int(infoC)
+		}
+	}
+
+	return &result, nil
+}
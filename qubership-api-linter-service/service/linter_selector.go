@@ -3,46 +3,137 @@ package service
 import (
 	"context"
 	"fmt"
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
 	"github.com/Netcracker/qubership-api-linter-service/repository"
 	"github.com/Netcracker/qubership-api-linter-service/view"
 )
 
 type LinterSelectorService interface {
-	SelectLinterAndRuleset(ctx context.Context, t view.ApiType) (view.Linter, string, error)
+	// SelectLinterAndRuleset returns the most specific ruleset for the package: bound to the package itself, to the closest
+	// parent group or workspace, or the globally active one. Empty packageId means the globally active ruleset.
+	SelectLinterAndRuleset(ctx context.Context, packageId string, t view.ApiType) (view.Linter, string, error)
 }
 
 type linterSelectorServiceImpl struct {
-	repo repository.RulesetRepository
+	repo           repository.RulesetRepository
+	bindingRepo    repository.RulesetBindingRepository
+	apihubClient   client.ApihubClient
+	linterRegistry LinterRegistry
 }
 
-func NewLinterSelectorService(repo repository.RulesetRepository) LinterSelectorService {
+func NewLinterSelectorService(repo repository.RulesetRepository, bindingRepo repository.RulesetBindingRepository, apihubClient client.ApihubClient, linterRegistry LinterRegistry) LinterSelectorService {
 	return &linterSelectorServiceImpl{
-		repo: repo,
+		repo:           repo,
+		bindingRepo:    bindingRepo,
+		apihubClient:   apihubClient,
+		linterRegistry: linterRegistry,
 	}
 }
 
-func (l linterSelectorServiceImpl) SelectLinterAndRuleset(ctx context.Context, t view.ApiType) (view.Linter, string, error) {
-	var linter view.Linter
-	var rulesetId string
+func (l linterSelectorServiceImpl) SelectLinterAndRuleset(ctx context.Context, packageId string, t view.ApiType) (view.Linter, string, error) {
+	linters := l.linterRegistry.GetLintersForApiType(t)
+	if len(linters) == 0 {
+		// lint of this type is not supported now
+		return view.UnknownLinter, "", nil
+	}
+
+	if packageId != "" {
+		rs, err := l.selectBoundRuleset(ctx, packageId, t)
+		if err != nil {
+			return view.UnknownLinter, "", err
+		}
+		if rs != nil {
+			return rs.Linter, rs.Id, nil
+		}
+	}
 
 	rulesets, err := l.repo.GetActiveRulesets(ctx, t)
 	if err != nil {
 		return view.UnknownLinter, "", err
 	}
 
-	switch t {
-	case view.OpenAPI31Type, view.OpenAPI30Type, view.OpenAPI20Type:
-		linter = view.SpectralLinter
-		rs, exists := rulesets[linter]
+	for _, linter := range linters {
+		rs, exists := rulesets[linter.GetLinter()]
+		if exists {
+			return linter.GetLinter(), rs.Id, nil
+		}
+	}
+
+	return linters[0].GetLinter(), "", fmt.Errorf("no active ruleset found for api type %s and linter %s", t, linters[0].GetLinter())
+}
+
+// selectBoundRuleset walks from the package up to the workspace and returns the first bound ruleset with supported linter
+func (l linterSelectorServiceImpl) selectBoundRuleset(ctx context.Context, packageId string, t view.ApiType) (*entity.Ruleset, error) {
+	pkg, err := l.apihubClient.GetPackageById(ctx, packageId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get package %s: %w", packageId, err)
+	}
+	if pkg == nil {
+		return nil, nil
+	}
+
+	scopeIds := makePackageScopeIds(*pkg)
+
+	bindings, err := l.bindingRepo.GetBindings(ctx, scopeIds, t)
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+	bindingsMap := make(map[string]entity.RulesetBinding)
+	for _, binding := range bindings {
+		bindingsMap[binding.PackageId] = binding
+	}
+
+	for _, scopeId := range scopeIds {
+		binding, exists := bindingsMap[scopeId]
 		if !exists {
-			return "", "", fmt.Errorf("no active ruleset found for api type %s and linter %s", t, linter)
+			continue
 		}
-		rulesetId = rs.Id
-		break
-	default:
-		// lint of this type is not supported now
-		linter = view.UnknownLinter
+		rs, err := l.repo.GetRulesetById(ctx, binding.RulesetId)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil || !l.linterRegistry.IsLinterSupported(rs.Linter) {
+			// e.g. the linter is disabled in this installation, the less specific ruleset is used instead
+			continue
+		}
+		latest, err := getSupersedingRevision(ctx, l.repo, *rs)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil {
+			// the bound revision is not active anymore, the less specific ruleset is used instead
+			continue
+		}
+		return rs, nil
 	}
+	return nil, nil
+}
+
+// getSupersedingRevision returns the latest revision of the ruleset lineage if the ruleset is not active and is superseded by it
+func getSupersedingRevision(ctx context.Context, repo repository.RulesetRepository, rs entity.Ruleset) (*entity.Ruleset, error) {
+	if rs.Status == view.RulesetStatusActive {
+		return nil, nil
+	}
+	latest, err := repo.GetRulesetByName(ctx, rs.Name, rs.ApiType)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || latest.Revision <= rs.Revision {
+		return nil, nil
+	}
+	return latest, nil
+}
 
-	return linter, rulesetId, nil
+// makePackageScopeIds returns ids of the package and its parents, from the package up to the workspace
+func makePackageScopeIds(pkg view.SimplePackage) []string {
+	// parents are listed from the workspace down to the direct parent
+	scopeIds := []string{pkg.Id}
+	for i := len(pkg.Parents) - 1; i >= 0; i-- {
+		scopeIds = append(scopeIds, pkg.Parents[i].Id)
+	}
+	return scopeIds
 }
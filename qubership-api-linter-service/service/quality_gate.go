@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+// QualityGateService manages quality gate policies and evaluates them when a version lint completes.
+// Policy of the package, group or workspace (the closest one) overrides policies of the rulesets used by the version.
+type QualityGateService interface {
+	GetRulesetQualityGate(ctx context.Context, rulesetId string) (*view.QualityGate, error)
+	SetRulesetQualityGate(ctx context.Context, rulesetId string, policy view.QualityGatePolicy) (*view.QualityGate, error)
+	DeleteRulesetQualityGate(ctx context.Context, rulesetId string) error
+	GetPackageQualityGate(ctx context.Context, packageId string) (*view.QualityGate, error)
+	SetPackageQualityGate(ctx context.Context, packageId string, policy view.QualityGatePolicy) (*view.QualityGate, error)
+	DeletePackageQualityGate(ctx context.Context, packageId string) error
+	// EvaluateVersion sets the verdict to the version, the verdict is empty if no policy is applicable.
+	// diff is nil if the previous version is not linted, diffErr is set if the diff failed.
+	EvaluateVersion(ctx context.Context, ver *entity.LintedVersion, diff *entity.VersionLintDiff, diffErr error) error
+	// ReevaluateVersions evaluates the verdict again for the package versions which have it and match the filter,
+	// e.g. when the waivers are changed. Failures are logged.
+	ReevaluateVersions(ctx context.Context, packageId string, versionFilter func(version string) bool)
+}
+
+func NewQualityGateService(qualityGateRepository repository.QualityGateRepository, rulesetRepository repository.RulesetRepository,
+	versionResultRepository repository.VersionResultRepository, lintResultRepository repository.LintResultRepository,
+	waiverRepository repository.WaiverRepository, linterRegistry LinterRegistry, lintDiffService LintDiffService,
+	apihubClient client.ApihubClient) QualityGateService {
+	return &qualityGateServiceImpl{
+		qualityGateRepository:   qualityGateRepository,
+		rulesetRepository:       rulesetRepository,
+		versionResultRepository: versionResultRepository,
+		lintResultRepository:    lintResultRepository,
+		waiverRepository:        waiverRepository,
+		linterRegistry:          linterRegistry,
+		lintDiffService:         lintDiffService,
+		apihubClient:            apihubClient,
+	}
+}
+
+type qualityGateServiceImpl struct {
+	qualityGateRepository   repository.QualityGateRepository
+	rulesetRepository       repository.RulesetRepository
+	versionResultRepository repository.VersionResultRepository
+	lintResultRepository    repository.LintResultRepository
+	waiverRepository        repository.WaiverRepository
+	linterRegistry          LinterRegistry
+	lintDiffService         LintDiffService
+	apihubClient            client.ApihubClient
+}
+
+func (q qualityGateServiceImpl) GetRulesetQualityGate(ctx context.Context, rulesetId string) (*view.QualityGate, error) {
+	ent, err := q.qualityGateRepository.GetRulesetQualityGate(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, makeQualityGateNotFoundError(rulesetId)
+	}
+	result := entity.MakeQualityGateView(ent.QualityGatePolicy, view.QualityGateScopeRuleset, rulesetId)
+	return &result, nil
+}
+
+func (q qualityGateServiceImpl) SetRulesetQualityGate(ctx context.Context, rulesetId string, policy view.QualityGatePolicy) (*view.QualityGate, error) {
+	err := validateQualityGatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := q.rulesetRepository.GetRulesetById(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+	ent := entity.RulesetQualityGate{
+		RulesetId:         rulesetId,
+		QualityGatePolicy: entity.MakeQualityGatePolicyEntity(policy, time.Now(), secctx.GetUserId(ctx)),
+	}
+	err = q.qualityGateRepository.SaveRulesetQualityGate(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Quality gate policy of ruleset %s (id = %s) was set by %s", rs.Name, rs.Id, ent.UpdatedBy)
+	result := entity.MakeQualityGateView(ent.QualityGatePolicy, view.QualityGateScopeRuleset, rulesetId)
+	return &result, nil
+}
+
+func (q qualityGateServiceImpl) DeleteRulesetQualityGate(ctx context.Context, rulesetId string) error {
+	deleted, err := q.qualityGateRepository.DeleteRulesetQualityGate(ctx, rulesetId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return makeQualityGateNotFoundError(rulesetId)
+	}
+	log.Infof("Quality gate policy of ruleset %s was removed", rulesetId)
+	return nil
+}
+
+func (q qualityGateServiceImpl) GetPackageQualityGate(ctx context.Context, packageId string) (*view.QualityGate, error) {
+	ent, err := q.qualityGateRepository.GetPackageQualityGate(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, makeQualityGateNotFoundError(packageId)
+	}
+	result := entity.MakeQualityGateView(ent.QualityGatePolicy, view.QualityGateScopePackage, packageId)
+	return &result, nil
+}
+
+func (q qualityGateServiceImpl) SetPackageQualityGate(ctx context.Context, packageId string, policy view.QualityGatePolicy) (*view.QualityGate, error) {
+	err := validateQualityGatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := q.apihubClient.GetPackageById(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "package", "id": packageId},
+		}
+	}
+	ent := entity.PackageQualityGate{
+		PackageId:         packageId,
+		QualityGatePolicy: entity.MakeQualityGatePolicyEntity(policy, time.Now(), secctx.GetUserId(ctx)),
+	}
+	err = q.qualityGateRepository.SavePackageQualityGate(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Quality gate policy of package %s was set by %s", packageId, ent.UpdatedBy)
+	result := entity.MakeQualityGateView(ent.QualityGatePolicy, view.QualityGateScopePackage, packageId)
+	return &result, nil
+}
+
+func (q qualityGateServiceImpl) DeletePackageQualityGate(ctx context.Context, packageId string) error {
+	deleted, err := q.qualityGateRepository.DeletePackageQualityGate(ctx, packageId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return makeQualityGateNotFoundError(packageId)
+	}
+	log.Infof("Quality gate policy of package %s was removed", packageId)
+	return nil
+}
+
+// qualityGateDoc is a document of the version with the issues which are checked by policies, waived issues are excluded
+type qualityGateDoc struct {
+	rulesetId  string
+	lintFailed bool
+	summary    view.IssuesSummary
+	introduced view.IssuesSummary
+}
+
+func (q qualityGateServiceImpl) EvaluateVersion(ctx context.Context, ver *entity.LintedVersion, diff *entity.VersionLintDiff, diffErr error) error {
+	ver.QualityGateStatus = ""
+	ver.QualityGateReasons = nil
+
+	docs, err := q.getQualityGateDocs(ctx, *ver, diff)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var reasons []string
+	pkgPolicy, err := q.selectPackagePolicy(ctx, ver.PackageId)
+	if err != nil {
+		return err
+	}
+	if pkgPolicy != nil {
+		reasons = checkQualityGatePolicy(pkgPolicy.QualityGatePolicy, fmt.Sprintf("package %s policy", pkgPolicy.PackageId), docs, diff, diffErr)
+	} else {
+		rulesetIds := make([]string, 0)
+		docsByRuleset := make(map[string][]qualityGateDoc)
+		for _, doc := range docs {
+			if _, exists := docsByRuleset[doc.rulesetId]; !exists {
+				rulesetIds = append(rulesetIds, doc.rulesetId)
+			}
+			docsByRuleset[doc.rulesetId] = append(docsByRuleset[doc.rulesetId], doc)
+		}
+		policies, err := q.qualityGateRepository.GetRulesetQualityGates(ctx, rulesetIds)
+		if err != nil {
+			return err
+		}
+		if len(policies) == 0 {
+			return nil
+		}
+		sort.Slice(policies, func(i, j int) bool {
+			return policies[i].RulesetId < policies[j].RulesetId
+		})
+		for _, policy := range policies {
+			rsName := policy.RulesetId
+			rs, err := q.rulesetRepository.GetRulesetById(ctx, policy.RulesetId)
+			if err != nil {
+				return err
+			}
+			if rs != nil {
+				rsName = rs.Name
+			}
+			reasons = append(reasons, checkQualityGatePolicy(policy.QualityGatePolicy, fmt.Sprintf("ruleset %s policy", rsName),
+				docsByRuleset[policy.RulesetId], diff, diffErr)...)
+		}
+	}
+
+	ver.QualityGateReasons = reasons
+	if len(reasons) == 0 {
+		ver.QualityGateStatus = view.QualityGatePassed
+	} else {
+		ver.QualityGateStatus = view.QualityGateFailed
+	}
+	log.Debugf("Quality gate for %s@%d of package %s is %s", ver.Version, ver.Revision, ver.PackageId, ver.QualityGateStatus)
+	return nil
+}
+
+func (q qualityGateServiceImpl) ReevaluateVersions(ctx context.Context, packageId string, versionFilter func(version string) bool) {
+	versions, err := q.versionResultRepository.GetVersionsWithQualityGate(ctx, packageId)
+	if err != nil {
+		log.Errorf("Failed to get versions of package %s with quality gate verdict: %s", packageId, err)
+		return
+	}
+	for _, ver := range versions {
+		if !versionFilter(ver.Version) {
+			continue
+		}
+		diff, diffErr := q.lintDiffService.BuildVersionDiff(ctx, ver)
+		if diffErr != nil {
+			log.Errorf("Failed to build lint diff for [ %s | %s@%d ]: %s", ver.PackageId, ver.Version, ver.Revision, diffErr)
+		}
+		err = q.EvaluateVersion(ctx, &ver, diff, diffErr)
+		if err != nil {
+			setQualityGateError(&ver, err)
+		}
+		updated, err := q.versionResultRepository.UpdateQualityGateVerdict(ctx, ver)
+		if err != nil {
+			log.Errorf("Failed to update quality gate verdict for [ %s | %s@%d ]: %s", ver.PackageId, ver.Version, ver.Revision, err)
+			continue
+		}
+		if !updated {
+			log.Debugf("Quality gate verdict for [ %s | %s@%d ] is not updated, the version was linted again", ver.PackageId, ver.Version, ver.Revision)
+		}
+	}
+}
+
+// setQualityGateError sets the error verdict, so the version lint result is kept if the quality gate can't be evaluated
+func setQualityGateError(ver *entity.LintedVersion, err error) {
+	log.Errorf("Failed to evaluate quality gate for [ %s | %s@%d ]: %s", ver.PackageId, ver.Version, ver.Revision, err)
+	ver.QualityGateStatus = view.QualityGateError
+	ver.QualityGateReasons = []string{fmt.Sprintf("failed to evaluate quality gate: %s", err)}
+}
+
+func (q qualityGateServiceImpl) getQualityGateDocs(ctx context.Context, ver entity.LintedVersion, diff *entity.VersionLintDiff) ([]qualityGateDoc, error) {
+	_, lintedDocs, err := q.versionResultRepository.GetVersionAndDocsSummary(ctx, ver.PackageId, ver.Version, ver.Revision)
+	if err != nil {
+		return nil, err
+	}
+	waivers, err := getVersionWaivers(ctx, q.waiverRepository, ver.PackageId, ver.Version)
+	if err != nil {
+		return nil, err
+	}
+	introduced := make(map[string]view.IssuesSummary)
+	if diff != nil {
+		for _, docDiff := range diff.Documents {
+			introducedSummary, _ := waivers.Summarize(docDiff.Slug, docDiff.Introduced)
+			introduced[docDiff.Slug] = introducedSummary
+		}
+	}
+
+	result := make([]qualityGateDoc, 0, len(lintedDocs))
+	for _, lintedDoc := range lintedDocs {
+		doc := qualityGateDoc{
+			rulesetId:  lintedDoc.RulesetId,
+			introduced: introduced[lintedDoc.Slug],
+		}
+		if lintedDoc.LintStatus == view.StatusError {
+			doc.lintFailed = true
+			result = append(result, doc)
+			continue
+		}
+		summary, err := q.getDocumentSummary(ctx, lintedDoc, waivers)
+		if err != nil {
+			return nil, err
+		}
+		doc.summary = *summary
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// getDocumentSummary returns the stored summary or counts the issues if some of them may be waived
+func (q qualityGateServiceImpl) getDocumentSummary(ctx context.Context, doc entity.LintedDocument, waivers VersionWaivers) (*view.IssuesSummary, error) {
+	rs, err := q.rulesetRepository.GetRulesetById(ctx, doc.RulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, fmt.Errorf("ruleset with id %s not found", doc.RulesetId)
+	}
+	linter, err := q.linterRegistry.GetLinter(rs.Linter)
+	if err != nil {
+		return nil, err
+	}
+
+	if waivers.HasDocumentWaivers(doc.Slug) {
+		lintResult, err := q.lintResultRepository.GetLintResult(ctx, doc.DataHash, doc.RulesetId)
+		if err != nil {
+			return nil, err
+		}
+		if lintResult == nil {
+			return nil, fmt.Errorf("lint result for document %s is not found", doc.Slug)
+		}
+		issues, err := linter.GetIssues(lintResult.Data)
+		if err != nil {
+			return nil, err
+		}
+		summary, _ := waivers.Summarize(doc.Slug, issues)
+		return &summary, nil
+	}
+
+	resultSummary, err := q.lintResultRepository.GetLintResultSummary(ctx, doc.DataHash, doc.RulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if resultSummary == nil {
+		return nil, fmt.Errorf("lint result summary for document %s is not found", doc.Slug)
+	}
+	summary, err := linter.ParseSummary(resultSummary.Summary)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("failed to calculate %s result summary", rs.Linter)
+	}
+	return summary, nil
+}
+
+// selectPackagePolicy returns the policy of the package or of the closest parent group or workspace
+func (q qualityGateServiceImpl) selectPackagePolicy(ctx context.Context, packageId string) (*entity.PackageQualityGate, error) {
+	pkg, err := q.apihubClient.GetPackageById(ctx, packageId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get package %s: %w", packageId, err)
+	}
+	if pkg == nil {
+		return nil, nil
+	}
+	scopeIds := makePackageScopeIds(*pkg)
+	policies, err := q.qualityGateRepository.GetPackageQualityGates(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	policiesMap := make(map[string]entity.PackageQualityGate)
+	for _, policy := range policies {
+		policiesMap[policy.PackageId] = policy
+	}
+	for _, scopeId := range scopeIds {
+		if policy, exists := policiesMap[scopeId]; exists {
+			return &policy, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkQualityGatePolicy returns the reasons why the documents don't pass the policy
+func checkQualityGatePolicy(policy entity.QualityGatePolicy, name string, docs []qualityGateDoc, diff *entity.VersionLintDiff, diffErr error) []string {
+	reasons := make([]string, 0)
+	var total, introduced view.IssuesSummary
+	failedDocs := 0
+	for _, doc := range docs {
+		if doc.lintFailed {
+			failedDocs++
+			continue
+		}
+		total.Append(doc.summary)
+		introduced.Append(doc.introduced)
+	}
+	if failedDocs > 0 {
+		// issues of the failed documents are unknown
+		reasons = append(reasons, fmt.Sprintf("%s: lint of %d document(s) failed", name, failedDocs))
+	}
+	if policy.MaxErrors != nil && total.Error > *policy.MaxErrors {
+		reasons = append(reasons, fmt.Sprintf("%s: %d error(s) found, at most %d allowed", name, total.Error, *policy.MaxErrors))
+	}
+	if policy.MaxWarnings != nil && total.Warning > *policy.MaxWarnings {
+		reasons = append(reasons, fmt.Sprintf("%s: %d warning(s) found, at most %d allowed", name, total.Warning, *policy.MaxWarnings))
+	}
+	if policy.NoNewErrors || policy.NoNewWarnings {
+		if diffErr != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: failed to compare with the previous version: %s", name, diffErr))
+			return reasons
+		}
+		if diff == nil {
+			// the first version or the previous version is not linted, there is nothing to compare with
+			return reasons
+		}
+		previousVersion := fmt.Sprintf("%s@%d", diff.PreviousVersion, diff.PreviousRevision)
+		if policy.NoNewErrors && introduced.Error > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s: %d new error(s) comparing to version %s", name, introduced.Error, previousVersion))
+		}
+		if policy.NoNewWarnings && introduced.Warning > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s: %d new warning(s) comparing to version %s", name, introduced.Warning, previousVersion))
+		}
+	}
+	return reasons
+}
+
+func validateQualityGatePolicy(policy view.QualityGatePolicy) error {
+	if policy.MaxErrors == nil && policy.MaxWarnings == nil && !policy.NoNewErrors && !policy.NoNewWarnings {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "maxErrors, maxWarnings, noNewErrors or noNewWarnings"},
+		}
+	}
+	if policy.MaxErrors != nil && *policy.MaxErrors < 0 {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "maxErrors", "value": *policy.MaxErrors},
+		}
+	}
+	if policy.MaxWarnings != nil && *policy.MaxWarnings < 0 {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "maxWarnings", "value": *policy.MaxWarnings},
+		}
+	}
+	return nil
+}
+
+func makeQualityGateNotFoundError(scopeId string) error {
+	return &exception.CustomError{
+		Status:  http.StatusNotFound,
+		Code:    exception.EntityNotFound,
+		Message: exception.EntityNotFoundMsg,
+		Params:  map[string]interface{}{"entity": "quality gate policy", "id": scopeId},
+	}
+}
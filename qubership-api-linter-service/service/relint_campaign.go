@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// RelintCampaignService re-lints recently linted versions of the packages which use the activated ruleset
+type RelintCampaignService interface {
+	StartCampaign(ctx context.Context, rulesetId string) (*view.RelintCampaign, error)
+	ListCampaigns(ctx context.Context, rulesetId string) ([]view.RelintCampaign, error)
+	GetCampaign(ctx context.Context, rulesetId string, campaignId string) (*view.RelintCampaign, error)
+}
+
+const relintCampaignCheckInterval = time.Second * 10
+
+func NewRelintCampaignService(campaignRepository repository.RelintCampaignRepository, rulesetRepository repository.RulesetRepository,
+	versionResultRepository repository.VersionResultRepository, verTaskRepo repository.VersionLintTaskRepository,
+	linterSelectorService LinterSelectorService, apihubClient client.ApihubClient,
+	versionsPerPackage int, maxActiveTasks int, executorId string) RelintCampaignService {
+	svc := &relintCampaignServiceImpl{
+		campaignRepository:      campaignRepository,
+		rulesetRepository:       rulesetRepository,
+		versionResultRepository: versionResultRepository,
+		verTaskRepo:             verTaskRepo,
+		linterSelectorService:   linterSelectorService,
+		apihubClient:            apihubClient,
+		versionsPerPackage:      versionsPerPackage,
+		maxActiveTasks:          maxActiveTasks,
+		executorId:              executorId,
+	}
+
+	utils.SafeAsync(func() {
+		svc.processCampaigns()
+	})
+
+	return svc
+}
+
+type relintCampaignServiceImpl struct {
+	campaignRepository      repository.RelintCampaignRepository
+	rulesetRepository       repository.RulesetRepository
+	versionResultRepository repository.VersionResultRepository
+	verTaskRepo             repository.VersionLintTaskRepository
+	linterSelectorService   LinterSelectorService
+	apihubClient            client.ApihubClient
+	versionsPerPackage      int
+	// max number of not finished version lint tasks of one campaign
+	maxActiveTasks int
+	executorId     string
+}
+
+func (r relintCampaignServiceImpl) StartCampaign(ctx context.Context, rulesetId string) (*view.RelintCampaign, error) {
+	rs, err := r.rulesetRepository.GetRulesetById(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+
+	versions, err := r.versionResultRepository.GetRecentLintedVersions(ctx, rs.ApiType, r.versionsPerPackage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linted versions: %w", err)
+	}
+
+	now := time.Now()
+	ent := entity.RelintCampaign{
+		Id:                 uuid.NewString(),
+		RulesetId:          rulesetId,
+		Status:             view.VersionStatusInProgress,
+		VersionsPerPackage: r.versionsPerPackage,
+		CreatedAt:          now,
+		CreatedBy:          secctx.GetUserId(ctx),
+		UpdatedAt:          now,
+	}
+	if len(versions) == 0 {
+		ent.Status = view.VersionStatusSuccess
+		ent.Details = "No linted versions to re-lint"
+	}
+	campaignVersions := make([]entity.RelintCampaignVersion, 0, len(versions))
+	for _, ver := range versions {
+		campaignVersions = append(campaignVersions, entity.RelintCampaignVersion{
+			CampaignId: ent.Id,
+			PackageId:  ver.PackageId,
+			Version:    ver.Version,
+			Revision:   ver.Revision,
+			Status:     view.RelintVersionPending,
+		})
+	}
+	err = r.campaignRepository.CreateCampaign(ctx, ent, campaignVersions)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Re-lint campaign %s for ruleset %s (id = %s) was started by %s, %d version(s) to re-lint",
+		ent.Id, rs.Name, rs.Id, ent.CreatedBy, len(campaignVersions))
+
+	result := entity.MakeRelintCampaignView(ent, map[view.RelintVersionStatus]int{view.RelintVersionPending: len(campaignVersions)})
+	return &result, nil
+}
+
+func (r relintCampaignServiceImpl) ListCampaigns(ctx context.Context, rulesetId string) ([]view.RelintCampaign, error) {
+	ents, err := r.campaignRepository.GetCampaigns(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]view.RelintCampaign, 0, len(ents))
+	for _, ent := range ents {
+		progress, err := r.campaignRepository.GetCampaignProgress(ctx, ent.Id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity.MakeRelintCampaignView(ent, progress))
+	}
+	return result, nil
+}
+
+func (r relintCampaignServiceImpl) GetCampaign(ctx context.Context, rulesetId string, campaignId string) (*view.RelintCampaign, error) {
+	ent, err := r.campaignRepository.GetCampaign(ctx, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.RulesetId != rulesetId {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "re-lint campaign", "id": campaignId},
+		}
+	}
+	progress, err := r.campaignRepository.GetCampaignProgress(ctx, ent.Id)
+	if err != nil {
+		return nil, err
+	}
+	result := entity.MakeRelintCampaignView(*ent, progress)
+	return &result, nil
+}
+
+// processCampaigns is running on every instance, each campaign is processed by the instance which claimed it
+func (r relintCampaignServiceImpl) processCampaigns() {
+	t := time.NewTicker(relintCampaignCheckInterval)
+	for range t.C {
+		ctx := secctx.MakeSysadminContext(context.Background())
+		campaigns, err := r.campaignRepository.GetRunningCampaigns(ctx)
+		if err != nil {
+			log.Errorf("Failed to get running re-lint campaigns: %s", err)
+			continue
+		}
+		for _, campaign := range campaigns {
+			claimed, err := r.campaignRepository.ClaimCampaign(ctx, campaign.Id, r.executorId)
+			if err != nil {
+				log.Errorf("Failed to claim re-lint campaign %s: %s", campaign.Id, err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+			utils.SafeSync(func() {
+				r.processCampaign(ctx, campaign)
+			})
+		}
+	}
+}
+
+// processCampaign enqueues the next pending versions while the number of not finished tasks of the campaign is below the limit
+func (r relintCampaignServiceImpl) processCampaign(ctx context.Context, campaign entity.RelintCampaign) {
+	rs, err := r.rulesetRepository.GetRulesetById(ctx, campaign.RulesetId)
+	if err != nil {
+		log.Errorf("Failed to get ruleset %s for re-lint campaign %s: %s", campaign.RulesetId, campaign.Id, err)
+		return
+	}
+	if rs == nil {
+		// the campaign is deleted together with the ruleset
+		return
+	}
+	if rs.Status != view.RulesetStatusActive {
+		err = r.campaignRepository.SkipPendingVersions(ctx, campaign.Id, "ruleset is not active anymore")
+		if err != nil {
+			log.Errorf("Failed to skip pending versions of re-lint campaign %s: %s", campaign.Id, err)
+			return
+		}
+	}
+
+	err = r.campaignRepository.SyncEnqueuedVersions(ctx, campaign.Id)
+	if err != nil {
+		log.Errorf("Failed to update versions of re-lint campaign %s: %s", campaign.Id, err)
+		return
+	}
+	progress, err := r.campaignRepository.GetCampaignProgress(ctx, campaign.Id)
+	if err != nil {
+		log.Errorf("Failed to get progress of re-lint campaign %s: %s", campaign.Id, err)
+		return
+	}
+	if progress[view.RelintVersionPending] == 0 && progress[view.RelintVersionEnqueued] == 0 {
+		err = r.campaignRepository.UpdateCampaignStatus(ctx, campaign.Id, view.VersionStatusSuccess, "")
+		if err != nil {
+			log.Errorf("Failed to complete re-lint campaign %s: %s", campaign.Id, err)
+			return
+		}
+		log.Infof("Re-lint campaign %s for ruleset %s (id = %s) finished: %d succeeded, %d failed, %d skipped",
+			campaign.Id, rs.Name, rs.Id, progress[view.RelintVersionSuccess], progress[view.RelintVersionError], progress[view.RelintVersionSkipped])
+		return
+	}
+
+	free := r.maxActiveTasks - progress[view.RelintVersionEnqueued]
+	if free <= 0 {
+		return
+	}
+	versions, err := r.campaignRepository.GetPendingVersions(ctx, campaign.Id, free)
+	if err != nil {
+		log.Errorf("Failed to get pending versions of re-lint campaign %s: %s", campaign.Id, err)
+		return
+	}
+	for _, ver := range versions {
+		r.enqueueVersion(ctx, campaign, *rs, &ver)
+		err = r.campaignRepository.UpdateCampaignVersion(ctx, ver)
+		if err != nil {
+			log.Errorf("Failed to update version %s@%s of re-lint campaign %s: %s", ver.PackageId, ver.Version, campaign.Id, err)
+		}
+	}
+}
+
+// enqueueVersion creates a low priority lint task for the latest revision of the version, the result is set to the campaign version
+func (r relintCampaignServiceImpl) enqueueVersion(ctx context.Context, campaign entity.RelintCampaign, rs entity.Ruleset, ver *entity.RelintCampaignVersion) {
+	versionContent, err := r.apihubClient.GetVersion(ctx, ver.PackageId, ver.Version)
+	if err != nil {
+		ver.Status = view.RelintVersionError
+		ver.Details = fmt.Sprintf("failed to get version: %s", err)
+		return
+	}
+	if versionContent == nil {
+		ver.Status = view.RelintVersionSkipped
+		ver.Details = "version not found"
+		return
+	}
+	_, revision, err := utils.SplitVersionRevision(versionContent.Version)
+	if err != nil {
+		ver.Status = view.RelintVersionError
+		ver.Details = err.Error()
+		return
+	}
+	if revision != 0 {
+		ver.Revision = revision
+	}
+
+	_, rulesetId, err := r.linterSelectorService.SelectLinterAndRuleset(ctx, ver.PackageId, rs.ApiType)
+	if err != nil {
+		ver.Status = view.RelintVersionError
+		ver.Details = fmt.Sprintf("failed to select ruleset: %s", err)
+		return
+	}
+	if rulesetId != rs.Id {
+		ver.Status = view.RelintVersionSkipped
+		ver.Details = fmt.Sprintf("package uses ruleset %s", rulesetId)
+		return
+	}
+
+	task := entity.VersionLintTask{
+		Id:           uuid.NewString(),
+		PackageId:    ver.PackageId,
+		Version:      ver.Version,
+		Revision:     ver.Revision,
+		Status:       view.TaskStatusNotStarted,
+		CreatedAt:    time.Now(),
+		CreatedBy:    campaign.CreatedBy,
+		LastActive:   time.Now(),
+		RestartCount: 0,
+		Priority:     view.TaskPriorityRelint,
+	}
+	err = r.verTaskRepo.SaveVersionTask(ctx, task)
+	if err != nil {
+		ver.Status = view.RelintVersionError
+		ver.Details = fmt.Sprintf("failed to create version lint task: %s", err)
+		return
+	}
+	ver.TaskId = task.Id
+	ver.Status = view.RelintVersionEnqueued
+	ver.Details = ""
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const graphqlBuiltinRuleset = "graphql-schema-linter"
+
+// document formats of the built-in rules, see "formats" of the spectral rules
+var apiTypeRuleFormats = map[view.ApiType][]string{
+	view.OpenAPI20Type: {"oas2"},
+	view.OpenAPI30Type: {"oas3", "oas3_0"},
+	view.OpenAPI31Type: {"oas3", "oas3_1"},
+	view.AsyncAPI2Type: {"aas2"},
+	view.AsyncAPI3Type: {"aas3"},
+}
+
+// RuleCatalogService describes rules of a ruleset including the rules inherited from parents and extended built-in rulesets
+type RuleCatalogService interface {
+	GetRuleCatalog(ctx context.Context, rulesetId string) (*view.RuleCatalog, error)
+	// AddRuleDescriptions fills descriptions of the issues, the issues are left as is if the catalog is not available
+	AddRuleDescriptions(ctx context.Context, rulesetId string, issues []view.ValidationIssue)
+}
+
+// NewRuleCatalogService loads the catalogs of the built-in rulesets from the directory
+func NewRuleCatalogService(rulesetRepository repository.RulesetRepository, builtinCatalogPath string) (RuleCatalogService, error) {
+	builtins, err := loadBuiltinRulesets(builtinCatalogPath)
+	if err != nil {
+		return nil, err
+	}
+	return &ruleCatalogServiceImpl{
+		rulesetRepository: rulesetRepository,
+		builtins:          builtins,
+		cache:             make(map[string]*view.RuleCatalog),
+	}, nil
+}
+
+type ruleCatalogServiceImpl struct {
+	rulesetRepository repository.RulesetRepository
+	builtins          map[string]builtinRuleset
+	// rulesets are immutable, so the catalogs are cached forever
+	cache      map[string]*view.RuleCatalog
+	cacheMutex sync.RWMutex
+}
+
+type builtinRuleset struct {
+	Name             string                 `yaml:"name"`
+	DocumentationUrl string                 `yaml:"documentationUrl"`
+	Rules            map[string]builtinRule `yaml:"rules"`
+}
+
+type builtinRule struct {
+	Description string   `yaml:"description"`
+	Severity    string   `yaml:"severity"`
+	Recommended *bool    `yaml:"recommended"`
+	Formats     []string `yaml:"formats"`
+	Given       []string `yaml:"given"`
+}
+
+func loadBuiltinRulesets(dir string) (map[string]builtinRuleset, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule catalog directory: %w", err)
+	}
+	result := make(map[string]builtinRuleset)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule catalog %s: %w", e.Name(), err)
+		}
+		var rs builtinRuleset
+		if err = yaml.Unmarshal(data, &rs); err != nil {
+			return nil, fmt.Errorf("failed to parse rule catalog %s: %w", e.Name(), err)
+		}
+		if rs.Name == "" {
+			return nil, fmt.Errorf("rule catalog %s has no name", e.Name())
+		}
+		result[rs.Name] = rs
+	}
+	return result, nil
+}
+
+func (c *ruleCatalogServiceImpl) GetRuleCatalog(ctx context.Context, rulesetId string) (*view.RuleCatalog, error) {
+	c.cacheMutex.RLock()
+	cached, exists := c.cache[rulesetId]
+	c.cacheMutex.RUnlock()
+	if exists {
+		return cached, nil
+	}
+
+	rs, err := c.rulesetRepository.GetRulesetWithData(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+
+	builder := ruleCatalogBuilder{
+		ctx:               ctx,
+		rulesetRepository: c.rulesetRepository,
+		builtins:          c.builtins,
+		apiType:           rs.ApiType,
+		rules:             make(map[string]*view.RuleDescription),
+		visited:           make(map[string]bool),
+	}
+	err = builder.addRuleset(*rs)
+	if err != nil {
+		return nil, err
+	}
+	result := builder.makeCatalog(entity.MakeRulesetView(rs.Ruleset))
+
+	c.cacheMutex.Lock()
+	c.cache[rulesetId] = result
+	c.cacheMutex.Unlock()
+	return result, nil
+}
+
+func (c *ruleCatalogServiceImpl) AddRuleDescriptions(ctx context.Context, rulesetId string, issues []view.ValidationIssue) {
+	if len(issues) == 0 || rulesetId == "" {
+		return
+	}
+	catalog, err := c.GetRuleCatalog(ctx, rulesetId)
+	if err != nil {
+		log.Debugf("Failed to get rule catalog of ruleset %s: %s", rulesetId, err)
+		return
+	}
+	descriptions := make(map[string]string, len(catalog.Rules))
+	for _, rule := range catalog.Rules {
+		descriptions[rule.Code] = rule.Description
+	}
+	for i := range issues {
+		if issues[i].Description == "" {
+			issues[i].Description = descriptions[issues[i].Code]
+		}
+	}
+}
+
+// ruleCatalogBuilder applies extends and rules in the same order as the linter does: parents, extends, own rules
+type ruleCatalogBuilder struct {
+	ctx               context.Context
+	rulesetRepository repository.RulesetRepository
+	builtins          map[string]builtinRuleset
+	apiType           view.ApiType
+	rules             map[string]*view.RuleDescription
+	unresolved        []string
+	visited           map[string]bool
+}
+
+func (b *ruleCatalogBuilder) makeCatalog(ruleset view.Ruleset) *view.RuleCatalog {
+	result := view.RuleCatalog{
+		Ruleset:    ruleset,
+		Rules:      make([]view.RuleDescription, 0, len(b.rules)),
+		Unresolved: b.unresolved,
+	}
+	for _, rule := range b.rules {
+		result.Rules = append(result.Rules, *rule)
+	}
+	sort.Slice(result.Rules, func(i, j int) bool {
+		return result.Rules[i].Code < result.Rules[j].Code
+	})
+	return &result
+}
+
+func (b *ruleCatalogBuilder) addRuleset(ruleset entity.RulesetWithData) error {
+	if b.visited[ruleset.Id] {
+		return nil
+	}
+	b.visited[ruleset.Id] = true
+
+	for _, parentId := range ruleset.Parents {
+		parent, err := b.rulesetRepository.GetRulesetWithData(b.ctx, parentId)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			b.unresolved = append(b.unresolved, parentId)
+			continue
+		}
+		if err = b.addRuleset(*parent); err != nil {
+			return err
+		}
+	}
+
+	if ruleset.Linter == view.GraphqlLinter {
+		b.addGraphqlRules(ruleset)
+		return nil
+	}
+
+	files, err := b.rulesetRepository.GetRulesetFiles(b.ctx, ruleset.Id)
+	if err != nil {
+		return err
+	}
+	fileData := make(map[string][]byte, len(files))
+	for _, file := range files {
+		fileData[file.Path] = file.Data
+	}
+	b.addDocument(ruleset.Id, ruleset.FileName, ruleset.Data, fileData, make(map[string]bool))
+	return nil
+}
+
+func (b *ruleCatalogBuilder) addGraphqlRules(ruleset entity.RulesetWithData) {
+	var config struct {
+		Rules []string `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(ruleset.Data, &config); err != nil {
+		b.unresolved = append(b.unresolved, ruleset.FileName)
+		return
+	}
+	builtin := b.builtins[graphqlBuiltinRuleset]
+	for _, code := range config.Rules {
+		rule := builtin.Rules[code]
+		b.rules[code] = &view.RuleDescription{
+			Code:             code,
+			Description:      rule.Description,
+			Severity:         rule.Severity,
+			DocumentationUrl: builtin.DocumentationUrl,
+			Source:           graphqlBuiltinRuleset,
+		}
+	}
+}
+
+// addDocument adds extends and rules of the ruleset file, files are the other files of the ruleset bundle which could be extended by relative paths
+func (b *ruleCatalogBuilder) addDocument(rulesetId string, filePath string, data []byte, files map[string][]byte, added map[string]bool) {
+	source := rulesetId
+	if added[filePath] {
+		return
+	}
+	if len(added) > 0 {
+		source = rulesetId + "/" + filePath
+	}
+	added[filePath] = true
+
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		b.unresolved = append(b.unresolved, source)
+		return
+	}
+	var doc map[interface{}]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		b.unresolved = append(b.unresolved, source)
+		return
+	}
+
+	for _, ext := range parseExtends(doc["extends"]) {
+		if _, isBuiltin := b.builtins[ext.name]; isBuiltin {
+			b.addBuiltin(ext.name, ext.mode)
+			continue
+		}
+		if strings.Contains(ext.name, "://") || path.IsAbs(ext.name) {
+			b.unresolved = append(b.unresolved, ext.name)
+			continue
+		}
+		extPath := path.Join(path.Dir(filePath), ext.name)
+		extData, exists := files[extPath]
+		if !exists {
+			b.unresolved = append(b.unresolved, ext.name)
+			continue
+		}
+		b.addDocument(rulesetId, extPath, extData, files, added)
+	}
+
+	documentationUrl, _ := doc["documentationUrl"].(string)
+	rules, _ := doc["rules"].(map[interface{}]interface{})
+	for key, value := range rules {
+		b.addRule(fmt.Sprint(key), value, source, documentationUrl)
+	}
+}
+
+func (b *ruleCatalogBuilder) addBuiltin(name string, mode string) {
+	builtin := b.builtins[name]
+	formats := apiTypeRuleFormats[b.apiType]
+	for code, rule := range builtin.Rules {
+		if len(rule.Formats) > 0 && !hasCommonItem(rule.Formats, formats) {
+			continue
+		}
+		severity := rule.Severity
+		recommended := rule.Recommended == nil || *rule.Recommended
+		if mode == "off" || (mode == "recommended" && !recommended) {
+			severity = "off"
+		}
+		b.rules[code] = &view.RuleDescription{
+			Code:             code,
+			Description:      rule.Description,
+			Severity:         severity,
+			Given:            rule.Given,
+			DocumentationUrl: builtin.DocumentationUrl + "#" + code,
+			Source:           name,
+		}
+	}
+}
+
+func (b *ruleCatalogBuilder) addRule(code string, value interface{}, source string, documentationUrl string) {
+	existing := b.rules[code]
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		_, hasGiven := v["given"]
+		_, hasThen := v["then"]
+		if existing == nil || hasGiven || hasThen {
+			// a new rule or a full override of the inherited one
+			existing = &view.RuleDescription{Code: code, Severity: defaultRuleSeverity, Source: source}
+			if documentationUrl != "" {
+				existing.DocumentationUrl = documentationUrl + "#" + code
+			}
+			b.rules[code] = existing
+		}
+		if description, ok := v["description"].(string); ok {
+			existing.Description = description
+		}
+		if severity, exists := v["severity"]; exists {
+			existing.Severity = normalizeRuleSeverity(severity)
+		}
+		if url, ok := v["documentationUrl"].(string); ok {
+			existing.DocumentationUrl = url
+		}
+		if given := parseRuleGiven(v["given"]); len(given) > 0 {
+			existing.Given = given
+		}
+	case bool:
+		if existing == nil {
+			existing = &view.RuleDescription{Code: code, Severity: defaultRuleSeverity, Source: source}
+			b.rules[code] = existing
+		}
+		if !v {
+			existing.Severity = "off"
+		} else if existing.Severity == "off" {
+			existing.Severity = b.getBuiltinSeverity(existing.Source, code)
+		}
+	default:
+		// severity override of the inherited rule
+		if existing == nil {
+			existing = &view.RuleDescription{Code: code, Source: source}
+			b.rules[code] = existing
+		}
+		existing.Severity = normalizeRuleSeverity(v)
+	}
+}
+
+// getBuiltinSeverity returns the severity of the rule which was disabled by the built-in ruleset mode
+func (b *ruleCatalogBuilder) getBuiltinSeverity(source string, code string) string {
+	if rule, exists := b.builtins[source].Rules[code]; exists {
+		return rule.Severity
+	}
+	return defaultRuleSeverity
+}
+
+type rulesetExtends struct {
+	name string
+	mode string
+}
+
+// parseExtends supports all the forms of spectral extends: "name", [name, mode] and the list of both
+func parseExtends(value interface{}) []rulesetExtends {
+	var result []rulesetExtends
+	switch v := value.(type) {
+	case string:
+		result = append(result, rulesetExtends{name: v, mode: "recommended"})
+	case []interface{}:
+		if len(v) == 0 {
+			return result
+		}
+		if isExtendsWithSeverity(v) {
+			return append(result, rulesetExtends{name: v[0].(string), mode: v[1].(string)})
+		}
+		// unquoted off is parsed as boolean
+		if len(v) == 2 {
+			if name, ok := v[0].(string); ok && v[1] == false {
+				return append(result, rulesetExtends{name: name, mode: "off"})
+			}
+		}
+		for _, item := range v {
+			result = append(result, parseExtends(item)...)
+		}
+	}
+	return result
+}
+
+func parseRuleGiven(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result
+	}
+	return nil
+}
+
+func hasCommonItem(a []string, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseExtends(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []rulesetExtends
+	}{
+		{"nil", nil, nil},
+		{"empty list", []interface{}{}, nil},
+		{"name", "spectral:oas", []rulesetExtends{{"spectral:oas", "recommended"}}},
+		{"name with mode", []interface{}{"spectral:oas", "all"}, []rulesetExtends{{"spectral:oas", "all"}}},
+		{"name with unquoted off", []interface{}{"spectral:oas", false}, []rulesetExtends{{"spectral:oas", "off"}}},
+		{"list of names", []interface{}{"spectral:oas", "./common.yaml"},
+			[]rulesetExtends{{"spectral:oas", "recommended"}, {"./common.yaml", "recommended"}}},
+		{"list of names with modes", []interface{}{[]interface{}{"spectral:oas", "off"}, "./common.yaml", []interface{}{"spectral:asyncapi", "all"}},
+			[]rulesetExtends{{"spectral:oas", "off"}, {"./common.yaml", "recommended"}, {"spectral:asyncapi", "all"}}},
+		{"list with empty item", []interface{}{[]interface{}{}, "spectral:oas"}, []rulesetExtends{{"spectral:oas", "recommended"}}},
+		{"unsupported value", 42, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := parseExtends(test.value); !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("parseExtends(%v) = %+v, expected %+v", test.value, actual, test.expected)
+			}
+		})
+	}
+}
@@ -7,66 +7,138 @@ import (
 	"github.com/Netcracker/qubership-api-linter-service/exception"
 	"github.com/Netcracker/qubership-api-linter-service/repository"
 	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
 	"github.com/Netcracker/qubership-api-linter-service/view"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"path"
+	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
 type RulesetService interface {
-	CreateRuleset(ctx context.Context, name string, apiType view.ApiType, linter view.Linter, filename string, data []byte) (*view.Ruleset, error)
-	ActivateRuleset(ctx context.Context, id string) error
+	CreateRuleset(ctx context.Context, req view.CreateRulesetRequest) (*view.Ruleset, error)
+	// ActivateRuleset activates the ruleset immediately, the re-lint campaign is returned if relint is requested
+	ActivateRuleset(ctx context.Context, id string, relint bool) (*view.RelintCampaign, error)
+	ScheduleActivation(ctx context.Context, id string, activateAt time.Time, relint bool) (*view.ActivationSchedule, error)
+	CancelScheduledActivation(ctx context.Context, id string) error
+	// RollbackActivation deactivates the active ruleset and activates the previously active ruleset for the same api type
+	RollbackActivation(ctx context.Context, id string) (*view.Ruleset, error)
 	ListRulesets(ctx context.Context, limit, page int) ([]view.Ruleset, error)
 	GetRuleset(ctx context.Context, id string) (*view.Ruleset, error)
 	GetRulesetData(ctx context.Context, id string) ([]byte, string, error)
-	GetActivationHistory(ctx context.Context, id string) ([]view.ActivationRecord, error)
+	GetActivationHistory(ctx context.Context, id string) (*view.ActivationHistoryResponse, error)
+	// ListRulesetRevisions returns all revisions of the ruleset lineage, the latest first
+	ListRulesetRevisions(ctx context.Context, id string) ([]view.Ruleset, error)
+	// GetRulesetDiff compares the ruleset with another revision of its lineage, the previous revision by default
+	GetRulesetDiff(ctx context.Context, id string, baseRevision int) (*view.RulesetDiff, error)
 	DeleteRuleset(ctx context.Context, id string) error
 }
 
-func NewRulesetService(rulesetRepository repository.RulesetRepository) RulesetService {
-	return &rulesetServiceImpl{rulesetRepository: rulesetRepository}
+const activationScheduleCheckInterval = time.Second * 30
+
+func NewRulesetService(rulesetRepository repository.RulesetRepository, linterRegistry LinterRegistry, rulesetMaterializer RulesetMaterializer,
+	relintCampaignService RelintCampaignService) RulesetService {
+	svc := &rulesetServiceImpl{rulesetRepository: rulesetRepository, linterRegistry: linterRegistry, rulesetMaterializer: rulesetMaterializer,
+		relintCampaignService: relintCampaignService}
+
+	utils.SafeAsync(func() {
+		svc.executeScheduledActivations()
+	})
+
+	return svc
 }
 
 type rulesetServiceImpl struct {
-	rulesetRepository repository.RulesetRepository
+	rulesetRepository     repository.RulesetRepository
+	linterRegistry        LinterRegistry
+	rulesetMaterializer   RulesetMaterializer
+	relintCampaignService RelintCampaignService
 }
 
-func (r rulesetServiceImpl) CreateRuleset(ctx context.Context, name string, apiType view.ApiType, linter view.Linter, filename string, data []byte) (*view.Ruleset, error) {
+func (r rulesetServiceImpl) CreateRuleset(ctx context.Context, req view.CreateRulesetRequest) (*view.Ruleset, error) {
 	userId := secctx.GetUserId(ctx)
 
-	exists, err := r.rulesetRepository.RulesetExists(ctx, name, apiType)
+	err := r.checkLinterSupported(req.Linter, req.ApiType)
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, &exception.CustomError{
-			Status:  http.StatusConflict,
-			Code:    exception.RulesetNameDuplicated,
-			Message: exception.RulesetNameDuplicatedMsg,
-			Params: map[string]interface{}{
-				"name": name,
-				"type": apiType,
-			},
+
+	latestRevision, err := r.rulesetRepository.GetRulesetByName(ctx, req.Name, req.ApiType)
+	if err != nil {
+		return nil, err
+	}
+	// a ruleset with the same name is a new revision of the lineage only if it's requested explicitly
+	revision := 1
+	if latestRevision != nil {
+		if !req.NewRevision {
+			return nil, &exception.CustomError{
+				Status:  http.StatusConflict,
+				Code:    exception.RulesetNameDuplicated,
+				Message: exception.RulesetNameDuplicatedMsg,
+				Params: map[string]interface{}{
+					"name": req.Name,
+					"type": req.ApiType,
+				},
+			}
 		}
+		revision = latestRevision.Revision + 1
+	}
+
+	fileName, data := req.FileName, req.Data
+	var bundleFiles []rulesetBundleFile
+	if isRulesetBundle(req.FileName) {
+		mainFile, otherFiles, err := unpackRulesetBundle(req.FileName, req.Data, req.MainFile)
+		if err != nil {
+			return nil, err
+		}
+		fileName, data, bundleFiles = path.Base(mainFile.path), mainFile.data, otherFiles
 	}
 
 	ent := entity.RulesetWithData{
 		Ruleset: entity.Ruleset{
 			Id:           uuid.NewString(),
-			Name:         name,
+			Name:         req.Name,
 			Status:       view.RulesetStatusInactive,
 			CreatedAt:    time.Now(),
 			CreatedBy:    userId,
-			ApiType:      apiType,
-			Linter:       linter,
-			FileName:     filename,
+			ApiType:      req.ApiType,
+			Linter:       req.Linter,
+			FileName:     fileName,
 			CanBeDeleted: true,
+			Revision:     revision,
 		},
 		Data: data,
 	}
-	err = r.rulesetRepository.CreateRuleset(ctx, ent)
+
+	ent.Parents, err = r.resolveParents(ctx, req.ApiType, req.Parents)
+	if err != nil {
+		return nil, err
+	}
+	// the new ruleset can't be a parent of anything yet, but the parents graph is validated as a whole
+	err = r.rulesetMaterializer.CheckDependencies(ctx, ent.Ruleset)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := makeRulesetFiles(ent.Id, req.Linter, bundleFiles, req.FunctionFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	linterExecutor, err := r.linterRegistry.GetLinter(req.Linter)
+	if err != nil {
+		return nil, err
+	}
+	err = validateRuleset(ctx, r.rulesetMaterializer, linterExecutor, ent, files)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.rulesetRepository.CreateRuleset(ctx, ent, files)
 	if err != nil {
 		return nil, err
 	}
@@ -83,37 +155,280 @@ func (r rulesetServiceImpl) CreateRuleset(ctx context.Context, name string, apiT
 	return &result, nil
 }
 
-func (r rulesetServiceImpl) ActivateRuleset(ctx context.Context, id string) error {
+func (r rulesetServiceImpl) ActivateRuleset(ctx context.Context, id string, relint bool) (*view.RelintCampaign, error) {
 	rsToActivate, err := r.rulesetRepository.GetRulesetById(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if rsToActivate == nil {
-		return fmt.Errorf("ruleset with id %s not found", id)
+		return nil, fmt.Errorf("ruleset with id %s not found", id)
+	}
+
+	currentR, err := r.getRulesetToDeactivate(ctx, *rsToActivate)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.rulesetRepository.ActivateRuleset(ctx, id, currentR.Id, view.ActivationTypeManual, "")
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Ruleset %s (id = %s) was activated for API type = %s and linter = %s",
+		rsToActivate.Name, rsToActivate.Id, rsToActivate.ApiType, rsToActivate.Linter)
+
+	// the pending activation makes no sense anymore
+	schedule, err := r.rulesetRepository.GetPendingActivationSchedule(ctx, id)
+	if err != nil {
+		log.Errorf("Failed to get scheduled activation of ruleset %s: %s", id, err)
+	} else if schedule != nil {
+		if _, err = r.rulesetRepository.CancelActivationSchedule(ctx, schedule.Id); err != nil {
+			log.Errorf("Failed to cancel scheduled activation of ruleset %s: %s", id, err)
+		}
+	}
+
+	if !relint {
+		return nil, nil
+	}
+	return r.relintCampaignService.StartCampaign(ctx, id)
+}
+
+// getRulesetToDeactivate returns the currently active ruleset which is replaced by the ruleset
+func (r rulesetServiceImpl) getRulesetToDeactivate(ctx context.Context, rsToActivate entity.Ruleset) (*entity.Ruleset, error) {
+	err := r.checkLinterSupported(rsToActivate.Linter, rsToActivate.ApiType)
+	if err != nil {
+		return nil, err
 	}
 
 	currentRs, err := r.rulesetRepository.GetActiveRulesets(ctx, rsToActivate.ApiType)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(currentRs) == 0 {
-		return fmt.Errorf("current active rulesets for api type %s are not found", rsToActivate.ApiType)
+		return nil, fmt.Errorf("current active rulesets for api type %s are not found", rsToActivate.ApiType)
 	}
 
-	currentR, exists := currentRs[rsToActivate.Linter]
-	if !exists {
-		return fmt.Errorf("current active ruleset for api type %s and linter %s is not found", rsToActivate.ApiType, rsToActivate.Linter)
+	// only one ruleset is active for api type, activation of a ruleset for another linter switches the linter as well
+	if len(currentRs) > 1 {
+		ids := make([]string, 0, len(currentRs))
+		for _, rs := range currentRs {
+			ids = append(ids, rs.Id)
+		}
+		sort.Strings(ids)
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.MultipleActiveRulesets,
+			Message: exception.MultipleActiveRulesetsMsg,
+			Params:  map[string]interface{}{"type": rsToActivate.ApiType, "ids": strings.Join(ids, ", ")},
+		}
 	}
+	var currentR entity.Ruleset
+	for _, rs := range currentRs {
+		currentR = rs
+	}
+	return &currentR, nil
+}
 
-	err = r.rulesetRepository.ActivateRuleset(ctx, id, currentR.Id)
+func (r rulesetServiceImpl) ScheduleActivation(ctx context.Context, id string, activateAt time.Time, relint bool) (*view.ActivationSchedule, error) {
+	rs, err := r.rulesetRepository.GetRulesetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": id},
+		}
+	}
+	err = r.checkLinterSupported(rs.Linter, rs.ApiType)
+	if err != nil {
+		return nil, err
+	}
+	if !activateAt.After(time.Now()) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "activateAt", "value": activateAt.Format(time.RFC3339)},
+			Debug:   "activation time has to be in the future",
+		}
+	}
+
+	pending, err := r.rulesetRepository.GetPendingActivationSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.RulesetActivationAlreadyScheduled,
+			Message: exception.RulesetActivationAlreadyScheduledMsg,
+			Params:  map[string]interface{}{"id": id, "activateAt": pending.ActivateAt.Format(time.RFC3339)},
+		}
+	}
+
+	ent := entity.RulesetActivationSchedule{
+		Id:         uuid.NewString(),
+		RulesetId:  id,
+		ActivateAt: activateAt.UTC(),
+		Status:     view.ActivationScheduled,
+		CreatedAt:  time.Now(),
+		CreatedBy:  secctx.GetUserId(ctx),
+		Relint:     relint,
+	}
+	err = r.rulesetRepository.CreateActivationSchedule(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Activation of ruleset %s (id = %s) was scheduled at %s by %s", rs.Name, rs.Id, ent.ActivateAt.Format(time.RFC3339), ent.CreatedBy)
+	result := entity.MakeActivationScheduleView(ent)
+	return &result, nil
+}
+
+func (r rulesetServiceImpl) CancelScheduledActivation(ctx context.Context, id string) error {
+	schedule, err := r.rulesetRepository.GetPendingActivationSchedule(ctx, id)
 	if err != nil {
 		return err
 	}
-	log.Infof("Ruleset %s (id = %s) was activated for API type = %s and linter = %s",
-		rsToActivate.Name, rsToActivate.Id, rsToActivate.ApiType, rsToActivate.Linter)
+	cancelled := false
+	if schedule != nil {
+		cancelled, err = r.rulesetRepository.CancelActivationSchedule(ctx, schedule.Id)
+		if err != nil {
+			return err
+		}
+	}
+	if !cancelled {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "scheduled activation of ruleset", "id": id},
+		}
+	}
+	log.Infof("Scheduled activation of ruleset %s was cancelled by %s", id, secctx.GetUserId(ctx))
 	return nil
 }
 
+func (r rulesetServiceImpl) RollbackActivation(ctx context.Context, id string) (*view.Ruleset, error) {
+	current, err := r.rulesetRepository.GetRulesetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": id},
+		}
+	}
+	if current.Status != view.RulesetStatusActive {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetRollbackNotPossible,
+			Message: exception.RulesetRollbackNotPossibleMsg,
+			Params:  map[string]interface{}{"id": id, "reason": "ruleset is not active"},
+		}
+	}
+	previous, err := r.rulesetRepository.GetPreviousActiveRuleset(ctx, current.ApiType, id)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetRollbackNotPossible,
+			Message: exception.RulesetRollbackNotPossibleMsg,
+			Params:  map[string]interface{}{"id": id, "reason": fmt.Sprintf("no ruleset was active for API type %s before", current.ApiType)},
+		}
+	}
+	err = r.checkLinterSupported(previous.Linter, previous.ApiType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.rulesetRepository.ActivateRuleset(ctx, previous.Id, id, view.ActivationTypeRollback, "")
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Activation of ruleset %s (id = %s) was rolled back, ruleset %s (id = %s) is active for API type = %s and linter = %s",
+		current.Name, current.Id, previous.Name, previous.Id, previous.ApiType, previous.Linter)
+
+	updated, err := r.rulesetRepository.GetRulesetById(ctx, previous.Id)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("ruleset with id %s not found", previous.Id)
+	}
+	result := entity.MakeRulesetView(*updated)
+	return &result, nil
+}
+
+// executeScheduledActivations is running on every instance, the schedule row lock makes sure that the activation is executed once
+func (r rulesetServiceImpl) executeScheduledActivations() {
+	t := time.NewTicker(activationScheduleCheckInterval)
+	for range t.C {
+		ctx := secctx.MakeSysadminContext(context.Background())
+		schedules, err := r.rulesetRepository.GetDueActivationSchedules(ctx, time.Now().UTC())
+		if err != nil {
+			log.Errorf("Failed to get scheduled ruleset activations: %s", err)
+			continue
+		}
+		for _, schedule := range schedules {
+			utils.SafeSync(func() {
+				r.executeScheduledActivation(ctx, schedule)
+			})
+		}
+	}
+}
+
+func (r rulesetServiceImpl) executeScheduledActivation(ctx context.Context, schedule entity.RulesetActivationSchedule) {
+	rs, err := r.rulesetRepository.GetRulesetById(ctx, schedule.RulesetId)
+	if err != nil {
+		log.Errorf("Failed to get ruleset %s for scheduled activation: %s", schedule.RulesetId, err)
+		return
+	}
+	if rs == nil {
+		// the schedule is deleted together with the ruleset
+		return
+	}
+	if rs.Status == view.RulesetStatusActive {
+		r.failScheduledActivation(ctx, schedule, "ruleset is already active")
+		return
+	}
+	currentR, err := r.getRulesetToDeactivate(ctx, *rs)
+	if err != nil {
+		r.failScheduledActivation(ctx, schedule, err.Error())
+		return
+	}
+	executed, err := r.rulesetRepository.ExecuteActivationSchedule(ctx, schedule, currentR.Id)
+	if err != nil {
+		r.failScheduledActivation(ctx, schedule, err.Error())
+		return
+	}
+	if !executed {
+		return
+	}
+	log.Infof("Ruleset %s (id = %s) was activated for API type = %s and linter = %s as scheduled by %s",
+		rs.Name, rs.Id, rs.ApiType, rs.Linter, schedule.CreatedBy)
+	if schedule.Relint {
+		_, err = r.relintCampaignService.StartCampaign(ctx, rs.Id)
+		if err != nil {
+			log.Errorf("Failed to start re-lint campaign for scheduled activation %s of ruleset %s: %s", schedule.Id, rs.Id, err)
+		}
+	}
+}
+
+func (r rulesetServiceImpl) failScheduledActivation(ctx context.Context, schedule entity.RulesetActivationSchedule, details string) {
+	log.Errorf("Scheduled activation %s of ruleset %s failed: %s", schedule.Id, schedule.RulesetId, details)
+	err := r.rulesetRepository.FailActivationSchedule(ctx, schedule.Id, details)
+	if err != nil {
+		log.Errorf("Failed to update scheduled activation %s: %s", schedule.Id, err)
+	}
+}
+
 func (r rulesetServiceImpl) ListRulesets(ctx context.Context, limit, page int) ([]view.Ruleset, error) {
 	ents, err := r.rulesetRepository.ListRulesets(ctx)
 	if err != nil {
@@ -199,10 +514,22 @@ func (r rulesetServiceImpl) GetRulesetData(ctx context.Context, id string) ([]by
 	if ent == nil {
 		return nil, "", nil
 	}
-	return ent.Data, ent.FileName, nil
+	files, err := r.rulesetRepository.GetRulesetFiles(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(files) == 0 {
+		return ent.Data, ent.FileName, nil
+	}
+	// ruleset with additional files is downloaded as a bundle which could be uploaded again
+	bundle, err := packRulesetBundle(*ent, files)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to pack ruleset bundle: %w", err)
+	}
+	return bundle, ent.Name + ".zip", nil
 }
 
-func (r rulesetServiceImpl) GetActivationHistory(ctx context.Context, id string) ([]view.ActivationRecord, error) {
+func (r rulesetServiceImpl) GetActivationHistory(ctx context.Context, id string) (*view.ActivationHistoryResponse, error) {
 	ent, err := r.rulesetRepository.GetRulesetById(ctx, id)
 	if err != nil {
 		return nil, err
@@ -220,13 +547,86 @@ func (r rulesetServiceImpl) GetActivationHistory(ctx context.Context, id string)
 	if err != nil {
 		return nil, err
 	}
-	result := make([]view.ActivationRecord, 0)
+	schedules, err := r.rulesetRepository.GetActivationSchedules(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := view.ActivationHistoryResponse{
+		Id:                id,
+		ActivationHistory: make([]view.ActivationRecord, 0),
+		Schedules:         make([]view.ActivationSchedule, 0),
+	}
 	for _, ent := range ents {
-		result = append(result, entity.MakeActivationRecordView(ent))
+		result.ActivationHistory = append(result.ActivationHistory, entity.MakeActivationRecordView(ent))
+	}
+	for _, schedule := range schedules {
+		result.Schedules = append(result.Schedules, entity.MakeActivationScheduleView(schedule))
+	}
+	return &result, nil
+}
+
+func (r rulesetServiceImpl) ListRulesetRevisions(ctx context.Context, id string) ([]view.Ruleset, error) {
+	ent, err := r.rulesetRepository.GetRulesetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": id},
+		}
+	}
+	ents, err := r.rulesetRepository.GetRulesetRevisions(ctx, ent.Name, ent.ApiType)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]view.Ruleset, 0, len(ents))
+	for _, rev := range ents {
+		result = append(result, entity.MakeRulesetView(rev))
 	}
 	return result, nil
 }
 
+func (r rulesetServiceImpl) GetRulesetDiff(ctx context.Context, id string, baseRevision int) (*view.RulesetDiff, error) {
+	target, err := r.rulesetRepository.GetRulesetWithData(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": id},
+		}
+	}
+	if baseRevision == 0 {
+		baseRevision = target.Revision - 1
+	}
+	base, err := r.rulesetRepository.GetRulesetRevision(ctx, target.Name, target.ApiType, baseRevision)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.RulesetRevisionNotFound,
+			Message: exception.RulesetRevisionNotFoundMsg,
+			Params:  map[string]interface{}{"revision": baseRevision, "name": target.Name, "type": target.ApiType},
+		}
+	}
+	baseWithData, err := r.rulesetRepository.GetRulesetWithData(ctx, base.Id)
+	if err != nil {
+		return nil, err
+	}
+	if baseWithData == nil {
+		return nil, fmt.Errorf("ruleset with id %s not found", base.Id)
+	}
+	return makeRulesetDiff(*baseWithData, *target)
+}
+
 func (r rulesetServiceImpl) DeleteRuleset(ctx context.Context, id string) error {
 	ent, err := r.rulesetRepository.GetRulesetById(ctx, id)
 	if err != nil {
@@ -240,6 +640,22 @@ func (r rulesetServiceImpl) DeleteRuleset(ctx context.Context, id string) error
 			Params:  map[string]interface{}{"entity": "ruleset", "id": id},
 		}
 	}
+	children, err := r.rulesetRepository.GetExtendingRulesets(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		childIds := make([]string, 0, len(children))
+		for _, child := range children {
+			childIds = append(childIds, child.Id)
+		}
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetIsExtended,
+			Message: exception.RulesetIsExtendedMsg,
+			Params:  map[string]interface{}{"id": id, "children": strings.Join(childIds, ", ")},
+		}
+	}
 	if !ent.CanBeDeleted {
 		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -256,3 +672,101 @@ func (r rulesetServiceImpl) DeleteRuleset(ctx context.Context, id string) error
 	log.Infof("Ruleset %s (id = %s) was deleted for API type = %s", ent.Name, ent.Id, ent.ApiType)
 	return nil
 }
+
+// resolveParents converts parent ids or names to unique ids
+func (r rulesetServiceImpl) resolveParents(ctx context.Context, apiType view.ApiType, parents []string) ([]string, error) {
+	result := make([]string, 0, len(parents))
+	added := make(map[string]bool)
+	for _, parent := range parents {
+		rs, err := r.rulesetRepository.GetRulesetById(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil {
+			rs, err = r.rulesetRepository.GetRulesetByName(ctx, parent, apiType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if rs == nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.RulesetParentNotFound,
+				Message: exception.RulesetParentNotFoundMsg,
+				Params:  map[string]interface{}{"parent": parent, "type": apiType},
+			}
+		}
+		if added[rs.Id] {
+			continue
+		}
+		added[rs.Id] = true
+		result = append(result, rs.Id)
+	}
+	return result, nil
+}
+
+// makeRulesetFiles merges files of the bundle and separately uploaded custom functions
+func makeRulesetFiles(rulesetId string, linter view.Linter, bundleFiles []rulesetBundleFile, functionFiles map[string][]byte) ([]entity.RulesetFile, error) {
+	if len(bundleFiles) == 0 && len(functionFiles) == 0 {
+		return nil, nil
+	}
+	if linter != view.SpectralLinter && linter != view.VacuumLinter {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetCompositionNotSupported,
+			Message: exception.RulesetCompositionNotSupportedMsg,
+			Params:  map[string]interface{}{"reason": fmt.Sprintf("additional ruleset files of linter %s", linter)},
+		}
+	}
+	files := make([]entity.RulesetFile, 0, len(bundleFiles)+len(functionFiles))
+	paths := make(map[string]bool)
+	for _, file := range bundleFiles {
+		files = append(files, entity.RulesetFile{
+			RulesetId: rulesetId,
+			Path:      file.path,
+			Data:      file.data,
+		})
+		paths[file.path] = true
+	}
+	for name, data := range functionFiles {
+		if name != filepath.Base(name) || !strings.EqualFold(filepath.Ext(name), ".js") {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectRulesetFile,
+				Message: exception.IncorrectRulesetFileMsg,
+				Params:  map[string]interface{}{"name": name, "reason": "only JavaScript function files (*.js) are supported"},
+			}
+		}
+		filePath := rulesetFunctionsDir + "/" + name
+		if paths[filePath] {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectRulesetFile,
+				Message: exception.IncorrectRulesetFileMsg,
+				Params:  map[string]interface{}{"name": name, "reason": "the file already exists in the ruleset bundle"},
+			}
+		}
+		files = append(files, entity.RulesetFile{
+			RulesetId: rulesetId,
+			Path:      filePath,
+			Data:      data,
+		})
+	}
+	return files, nil
+}
+
+func (r rulesetServiceImpl) checkLinterSupported(linter view.Linter, apiType view.ApiType) error {
+	executor, err := r.linterRegistry.GetLinter(linter)
+	if err != nil || !executor.SupportsApiType(apiType) {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.LinterNotSupportedForApiType,
+			Message: exception.LinterNotSupportedForApiTypeMsg,
+			Params: map[string]interface{}{
+				"linter": linter,
+				"type":   apiType,
+			},
+		}
+	}
+	return nil
+}
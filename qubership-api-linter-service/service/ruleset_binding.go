@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+// RulesetBindingService manages rulesets bound to a package, group or workspace
+type RulesetBindingService interface {
+	ListBindings(ctx context.Context, packageId string) (*view.RulesetBindingsResponse, error)
+	BindRuleset(ctx context.Context, packageId string, apiType view.ApiType, rulesetId string) (*view.RulesetBinding, error)
+	UnbindRuleset(ctx context.Context, packageId string, apiType view.ApiType) error
+	GetBindingHistory(ctx context.Context, packageId string) (*view.RulesetBindingHistoryResponse, error)
+}
+
+func NewRulesetBindingService(bindingRepository repository.RulesetBindingRepository, rulesetRepository repository.RulesetRepository,
+	apihubClient client.ApihubClient, linterRegistry LinterRegistry) RulesetBindingService {
+	return &rulesetBindingServiceImpl{
+		bindingRepository: bindingRepository,
+		rulesetRepository: rulesetRepository,
+		apihubClient:      apihubClient,
+		linterRegistry:    linterRegistry,
+	}
+}
+
+type rulesetBindingServiceImpl struct {
+	bindingRepository repository.RulesetBindingRepository
+	rulesetRepository repository.RulesetRepository
+	apihubClient      client.ApihubClient
+	linterRegistry    LinterRegistry
+}
+
+func (r rulesetBindingServiceImpl) ListBindings(ctx context.Context, packageId string) (*view.RulesetBindingsResponse, error) {
+	ents, err := r.bindingRepository.ListPackageBindings(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.RulesetBindingsResponse{
+		PackageId: packageId,
+		Bindings:  make([]view.RulesetBinding, 0),
+	}
+	for _, ent := range ents {
+		rs, err := r.rulesetRepository.GetRulesetById(ctx, ent.RulesetId)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil {
+			continue
+		}
+		result.Bindings = append(result.Bindings, entity.MakeRulesetBindingView(ent, *rs))
+	}
+	return result, nil
+}
+
+func (r rulesetBindingServiceImpl) BindRuleset(ctx context.Context, packageId string, apiType view.ApiType, rulesetId string) (*view.RulesetBinding, error) {
+	pkg, err := r.apihubClient.GetPackageById(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "package", "id": packageId},
+		}
+	}
+
+	rs, err := r.rulesetRepository.GetRulesetById(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+	if rs.ApiType != apiType {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetApiTypeMismatch,
+			Message: exception.RulesetApiTypeMismatchMsg,
+			Params:  map[string]interface{}{"id": rulesetId, "rulesetType": rs.ApiType, "type": apiType},
+		}
+	}
+	latest, err := getSupersedingRevision(ctx, r.rulesetRepository, *rs)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetSuperseded,
+			Message: exception.RulesetSupersededMsg,
+			Params:  map[string]interface{}{"id": rulesetId, "revision": rs.Revision, "name": rs.Name, "latestRevision": latest.Revision},
+		}
+	}
+	executor, err := r.linterRegistry.GetLinter(rs.Linter)
+	if err != nil || !executor.SupportsApiType(rs.ApiType) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.LinterNotSupportedForApiType,
+			Message: exception.LinterNotSupportedForApiTypeMsg,
+			Params:  map[string]interface{}{"linter": rs.Linter, "type": rs.ApiType},
+		}
+	}
+
+	ent := entity.RulesetBinding{
+		PackageId: packageId,
+		ApiType:   apiType,
+		RulesetId: rulesetId,
+		CreatedAt: time.Now(),
+		CreatedBy: secctx.GetUserId(ctx),
+	}
+	err = r.bindingRepository.BindRuleset(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Ruleset %s (id = %s) was bound to package %s for API type = %s", rs.Name, rs.Id, packageId, apiType)
+
+	result := entity.MakeRulesetBindingView(ent, *rs)
+	return &result, nil
+}
+
+func (r rulesetBindingServiceImpl) UnbindRuleset(ctx context.Context, packageId string, apiType view.ApiType) error {
+	deleted, err := r.bindingRepository.UnbindRuleset(ctx, packageId, apiType)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset binding", "id": string(apiType)},
+		}
+	}
+	log.Infof("Ruleset binding for API type = %s was removed from package %s", apiType, packageId)
+	return nil
+}
+
+func (r rulesetBindingServiceImpl) GetBindingHistory(ctx context.Context, packageId string) (*view.RulesetBindingHistoryResponse, error) {
+	ents, err := r.bindingRepository.GetBindingHistory(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.RulesetBindingHistoryResponse{
+		PackageId:         packageId,
+		ActivationHistory: make([]view.RulesetBindingHistoryRecord, 0),
+	}
+	for _, ent := range ents {
+		result.ActivationHistory = append(result.ActivationHistory, entity.MakeRulesetBindingHistoryView(ent))
+	}
+	return result, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+)
+
+const (
+	rulesetBundleMaxFiles = 500
+	rulesetBundleMaxSize  = 20 * 1024 * 1024
+)
+
+type rulesetBundleFile struct {
+	path string
+	data []byte
+}
+
+func isRulesetBundle(fileName string) bool {
+	name := strings.ToLower(fileName)
+	for _, ext := range []string{".zip", ".tar", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// unpackRulesetBundle returns the main ruleset file and the rest of the bundle files with paths relative to the main file.
+// If mainFile is empty, the only YAML/JSON/JS file in the bundle root is used.
+func unpackRulesetBundle(bundleName string, data []byte, mainFile string) (*rulesetBundleFile, []rulesetBundleFile, error) {
+	var files []rulesetBundleFile
+	var err error
+	name := strings.ToLower(bundleName)
+	if strings.HasSuffix(name, ".zip") {
+		files, err = readZipBundle(data)
+	} else {
+		files, err = readTarBundle(data, !strings.HasSuffix(name, ".tar"))
+	}
+	if err != nil {
+		return nil, nil, makeIncorrectBundleError(bundleName, err.Error())
+	}
+	if len(files) == 0 {
+		return nil, nil, makeIncorrectBundleError(bundleName, "bundle is empty")
+	}
+	files = trimCommonDir(files)
+
+	mainIdx := -1
+	for i, file := range files {
+		if mainFile != "" {
+			if file.path == path.Clean(mainFile) {
+				mainIdx = i
+			}
+			continue
+		}
+		if strings.Contains(file.path, "/") {
+			continue
+		}
+		switch strings.ToLower(path.Ext(file.path)) {
+		case ".yaml", ".yml", ".json", ".js":
+			if mainIdx >= 0 {
+				return nil, nil, makeIncorrectBundleError(bundleName, "several ruleset files found in the bundle root, the main file has to be specified")
+			}
+			mainIdx = i
+		}
+	}
+	if mainIdx < 0 {
+		return nil, nil, makeIncorrectBundleError(bundleName, "main ruleset file not found in the bundle root")
+	}
+	if strings.Contains(files[mainIdx].path, "/") {
+		return nil, nil, makeIncorrectBundleError(bundleName, "main ruleset file has to be in the bundle root")
+	}
+
+	main := files[mainIdx]
+	rest := make([]rulesetBundleFile, 0, len(files)-1)
+	rest = append(rest, files[:mainIdx]...)
+	rest = append(rest, files[mainIdx+1:]...)
+	return &main, rest, nil
+}
+
+func readZipBundle(data []byte) ([]rulesetBundleFile, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	var files []rulesetBundleFile
+	totalSize := 0
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			return nil, fmt.Errorf("%s is not a regular file", f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		fileData, err := io.ReadAll(io.LimitReader(rc, int64(rulesetBundleMaxSize-totalSize+1)))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		totalSize += len(fileData)
+		files, err = appendBundleFile(files, f.Name, fileData, totalSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func readTarBundle(data []byte, compressed bool) ([]rulesetBundleFile, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if compressed {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	}
+	reader := tar.NewReader(r)
+	var files []rulesetBundleFile
+	totalSize := 0
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir, tar.TypeXGlobalHeader:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("%s is not a regular file", header.Name)
+		}
+		fileData, err := io.ReadAll(io.LimitReader(reader, int64(rulesetBundleMaxSize-totalSize+1)))
+		if err != nil {
+			return nil, err
+		}
+		totalSize += len(fileData)
+		files, err = appendBundleFile(files, header.Name, fileData, totalSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func appendBundleFile(files []rulesetBundleFile, name string, data []byte, totalSize int) ([]rulesetBundleFile, error) {
+	if totalSize > rulesetBundleMaxSize {
+		return nil, fmt.Errorf("bundle size exceeds %d bytes", rulesetBundleMaxSize)
+	}
+	if len(files) >= rulesetBundleMaxFiles {
+		return nil, fmt.Errorf("bundle contains more than %d files", rulesetBundleMaxFiles)
+	}
+	p := path.Clean(strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "./"))
+	if path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+		return nil, fmt.Errorf("file path %s is outside of the bundle", name)
+	}
+	// skip OS metadata
+	if strings.HasPrefix(p, "__MACOSX/") || strings.Contains(p, "/__MACOSX/") || path.Base(p) == ".DS_Store" {
+		return files, nil
+	}
+	return append(files, rulesetBundleFile{path: p, data: data}), nil
+}
+
+// trimCommonDir removes the root directory if the whole bundle was packed in it
+func trimCommonDir(files []rulesetBundleFile) []rulesetBundleFile {
+	first := strings.SplitN(files[0].path, "/", 2)
+	if len(first) < 2 {
+		return files
+	}
+	prefix := first[0] + "/"
+	for _, file := range files {
+		if !strings.HasPrefix(file.path, prefix) {
+			return files
+		}
+	}
+	for i := range files {
+		files[i].path = strings.TrimPrefix(files[i].path, prefix)
+	}
+	return files
+}
+
+// packRulesetBundle packs the ruleset and its files to a zip archive
+func packRulesetBundle(ruleset entity.RulesetWithData, files []entity.RulesetFile) ([]byte, error) {
+	buf := bytes.Buffer{}
+	writer := zip.NewWriter(&buf)
+	w, err := writer.Create(ruleset.FileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(ruleset.Data); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		w, err = writer.Create(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(file.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func makeIncorrectBundleError(bundleName string, reason string) error {
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.IncorrectRulesetBundle,
+		Message: exception.IncorrectRulesetBundleMsg,
+		Params:  map[string]interface{}{"name": bundleName, "reason": reason},
+	}
+}
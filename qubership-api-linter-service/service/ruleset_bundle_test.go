@@ -0,0 +1,213 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+)
+
+type testBundleEntry struct {
+	name string
+	data []byte
+}
+
+func makeTestZip(t *testing.T, entries []testBundleEntry) []byte {
+	t.Helper()
+	buf := bytes.Buffer{}
+	writer := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func makeTestTarGz(t *testing.T, entries []testBundleEntry) []byte {
+	t.Helper()
+	buf := bytes.Buffer{}
+	gw := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gw)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(entry.data))}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func assertIncorrectBundle(t *testing.T, err error, reason string) {
+	t.Helper()
+	var customErr *exception.CustomError
+	if !errors.As(err, &customErr) {
+		t.Fatalf("expected incorrect bundle error, got %v", err)
+	}
+	if customErr.Code != exception.IncorrectRulesetBundle {
+		t.Fatalf("expected error code %s, got %s", exception.IncorrectRulesetBundle, customErr.Code)
+	}
+	if actual := fmt.Sprint(customErr.Params["reason"]); !strings.Contains(actual, reason) {
+		t.Fatalf("expected reason containing %q, got %q", reason, actual)
+	}
+}
+
+func getBundlePaths(files []rulesetBundleFile) []string {
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		result = append(result, file.path)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestUnpackRulesetBundle_RejectsPathsOutsideOfBundle(t *testing.T) {
+	for _, name := range []string{"../ruleset.yaml", "rules/../../ruleset.yaml", "/etc/ruleset.yaml", "..\\ruleset.yaml", "\\etc\\ruleset.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			entries := []testBundleEntry{{"ruleset.yaml", []byte("rules: {}")}, {name, []byte("x")}}
+
+			_, _, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), "")
+			assertIncorrectBundle(t, err, "outside of the bundle")
+
+			_, _, err = unpackRulesetBundle("bundle.tar.gz", makeTestTarGz(t, entries), "")
+			assertIncorrectBundle(t, err, "outside of the bundle")
+		})
+	}
+}
+
+func TestUnpackRulesetBundle_NormalizesBackslashPaths(t *testing.T) {
+	entries := []testBundleEntry{
+		{"bundle\\ruleset.yaml", []byte("extends: ./rules/common.yaml")},
+		{"bundle\\rules\\common.yaml", []byte("rules: {}")},
+		{"bundle/functions/./check.js", []byte("export default () => {}")},
+	}
+	main, rest, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if main.path != "ruleset.yaml" {
+		t.Errorf("expected main file ruleset.yaml, got %s", main.path)
+	}
+	expected := []string{"functions/check.js", "rules/common.yaml"}
+	if actual := getBundlePaths(rest); strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected files %v, got %v", expected, actual)
+	}
+}
+
+func TestUnpackRulesetBundle_SkipsOsMetadata(t *testing.T) {
+	entries := []testBundleEntry{
+		{"ruleset.yaml", []byte("rules: {}")},
+		{"__MACOSX/._ruleset.yaml", []byte("x")},
+		{"rules/.DS_Store", []byte("x")},
+	}
+	_, rest, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("expected no files except main, got %v", getBundlePaths(rest))
+	}
+}
+
+func TestUnpackRulesetBundle_SizeLimit(t *testing.T) {
+	entries := []testBundleEntry{
+		{"ruleset.yaml", []byte("rules: {}")},
+		{"large.json", bytes.Repeat([]byte(" "), rulesetBundleMaxSize)},
+	}
+	_, _, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), "")
+	assertIncorrectBundle(t, err, "bundle size exceeds")
+
+	_, _, err = unpackRulesetBundle("bundle.tgz", makeTestTarGz(t, entries), "")
+	assertIncorrectBundle(t, err, "bundle size exceeds")
+}
+
+func TestUnpackRulesetBundle_FileCountLimit(t *testing.T) {
+	entries := []testBundleEntry{{"ruleset.yaml", []byte("rules: {}")}}
+	for i := 1; i < rulesetBundleMaxFiles; i++ {
+		entries = append(entries, testBundleEntry{fmt.Sprintf("rules/%d.yaml", i), []byte("rules: {}")})
+	}
+	if _, _, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), ""); err != nil {
+		t.Fatalf("bundle with %d files is expected to be accepted: %v", rulesetBundleMaxFiles, err)
+	}
+
+	entries = append(entries, testBundleEntry{"rules/extra.yaml", []byte("rules: {}")})
+	_, _, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), "")
+	assertIncorrectBundle(t, err, "more than")
+}
+
+func TestUnpackRulesetBundle_MainFileSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []string
+		mainFile string
+		expected string
+		reason   string
+	}{
+		{name: "single root file", entries: []string{"ruleset.yaml", "rules/common.yaml"}, expected: "ruleset.yaml"},
+		{name: "js root file", entries: []string{"ruleset.js", "functions/check.js"}, expected: "ruleset.js"},
+		{name: "common root dir", entries: []string{"dir/ruleset.json", "dir/rules/common.yaml"}, expected: "ruleset.json"},
+		{name: "non ruleset root files ignored", entries: []string{"README.md", "ruleset.yml"}, expected: "ruleset.yml"},
+		{name: "several root files", entries: []string{"a.yaml", "b.yaml"}, reason: "main file has to be specified"},
+		{name: "specified main file", entries: []string{"a.yaml", "b.yaml"}, mainFile: "b.yaml", expected: "b.yaml"},
+		{name: "specified main file not cleaned", entries: []string{"a.yaml", "b.yaml"}, mainFile: "./b.yaml", expected: "b.yaml"},
+		{name: "specified main file missing", entries: []string{"a.yaml"}, mainFile: "c.yaml", reason: "main ruleset file not found"},
+		{name: "specified main file nested", entries: []string{"a.yaml", "rules/b.yaml"}, mainFile: "rules/b.yaml", reason: "has to be in the bundle root"},
+		{name: "no root files", entries: []string{"a/ruleset.yaml", "b/ruleset.yaml"}, reason: "main ruleset file not found"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var entries []testBundleEntry
+			for _, name := range test.entries {
+				entries = append(entries, testBundleEntry{name, []byte("rules: {}")})
+			}
+			main, rest, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, entries), test.mainFile)
+			if test.reason != "" {
+				assertIncorrectBundle(t, err, test.reason)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if main.path != test.expected {
+				t.Errorf("expected main file %s, got %s", test.expected, main.path)
+			}
+			if len(rest) != len(test.entries)-1 {
+				t.Errorf("expected %d other files, got %v", len(test.entries)-1, getBundlePaths(rest))
+			}
+		})
+	}
+}
+
+func TestUnpackRulesetBundle_EmptyAndCorrupted(t *testing.T) {
+	_, _, err := unpackRulesetBundle("bundle.zip", makeTestZip(t, nil), "")
+	assertIncorrectBundle(t, err, "bundle is empty")
+
+	_, _, err = unpackRulesetBundle("bundle.zip", []byte("not a zip"), "")
+	assertIncorrectBundle(t, err, "")
+
+	_, _, err = unpackRulesetBundle("bundle.tar.gz", []byte("not a tar"), "")
+	assertIncorrectBundle(t, err, "")
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"gopkg.in/yaml.v2"
+)
+
+// spectral and vacuum use "warn" for rules without explicit severity
+const defaultRuleSeverity = "warn"
+
+var numericRuleSeverities = []string{"error", "warn", "info", "hint"}
+
+type rulesetRule struct {
+	severity   string
+	definition interface{} // rule definition without severity
+}
+
+type parsedRuleset struct {
+	rules   map[string]rulesetRule
+	extends []string
+}
+
+// makeRulesetDiff compares own rules and extends of the main ruleset files, inherited rules are not resolved
+func makeRulesetDiff(base entity.RulesetWithData, target entity.RulesetWithData) (*view.RulesetDiff, error) {
+	baseRules, err := parseRulesetRules(base)
+	if err != nil {
+		return nil, err
+	}
+	targetRules, err := parseRulesetRules(target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := view.RulesetDiff{
+		Base:            entity.MakeRulesetView(base.Ruleset),
+		Target:          entity.MakeRulesetView(target.Ruleset),
+		Added:           make([]view.RulesetDiffRule, 0),
+		Removed:         make([]view.RulesetDiffRule, 0),
+		SeverityChanged: make([]view.RulesetDiffRule, 0),
+		Modified:        make([]view.RulesetDiffRule, 0),
+		ExtendsAdded:    diffStrings(targetRules.extends, baseRules.extends),
+		ExtendsRemoved:  diffStrings(baseRules.extends, targetRules.extends),
+	}
+	for _, name := range sortedRuleNames(targetRules.rules) {
+		rule := targetRules.rules[name]
+		baseRule, exists := baseRules.rules[name]
+		switch {
+		case !exists:
+			result.Added = append(result.Added, view.RulesetDiffRule{Name: name, Severity: rule.severity})
+		case baseRule.severity != rule.severity:
+			result.SeverityChanged = append(result.SeverityChanged, view.RulesetDiffRule{Name: name, Severity: rule.severity, PreviousSeverity: baseRule.severity})
+		case !reflect.DeepEqual(baseRule.definition, rule.definition):
+			result.Modified = append(result.Modified, view.RulesetDiffRule{Name: name, Severity: rule.severity})
+		}
+	}
+	for _, name := range sortedRuleNames(baseRules.rules) {
+		if _, exists := targetRules.rules[name]; !exists {
+			result.Removed = append(result.Removed, view.RulesetDiffRule{Name: name, Severity: baseRules.rules[name].severity})
+		}
+	}
+	return &result, nil
+}
+
+func parseRulesetRules(ruleset entity.RulesetWithData) (*parsedRuleset, error) {
+	switch strings.ToLower(filepath.Ext(ruleset.FileName)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetDiffNotSupported,
+			Message: exception.RulesetDiffNotSupportedMsg,
+			Params:  map[string]interface{}{"id": ruleset.Id, "reason": fmt.Sprintf("ruleset file %s is not a YAML/JSON file", ruleset.FileName)},
+		}
+	}
+	var doc map[interface{}]interface{}
+	if err := yaml.Unmarshal(ruleset.Data, &doc); err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetDiffNotSupported,
+			Message: exception.RulesetDiffNotSupportedMsg,
+			Params:  map[string]interface{}{"id": ruleset.Id, "reason": fmt.Sprintf("ruleset file is not a valid YAML/JSON: %s", err)},
+		}
+	}
+
+	result := parsedRuleset{rules: make(map[string]rulesetRule)}
+	switch rules := doc["rules"].(type) {
+	case map[interface{}]interface{}:
+		for name, value := range rules {
+			result.rules[fmt.Sprint(name)] = makeRulesetRule(value)
+		}
+	case []interface{}:
+		// graphql-schema-linter config is a list of rule names
+		for _, name := range rules {
+			result.rules[fmt.Sprint(name)] = rulesetRule{}
+		}
+	}
+	switch extends := doc["extends"].(type) {
+	case nil:
+	case []interface{}:
+		if isExtendsWithSeverity(extends) {
+			result.extends = append(result.extends, fmt.Sprint(extends))
+			break
+		}
+		for _, item := range extends {
+			result.extends = append(result.extends, fmt.Sprint(item))
+		}
+	default:
+		result.extends = append(result.extends, fmt.Sprint(extends))
+	}
+	return &result, nil
+}
+
+func makeRulesetRule(value interface{}) rulesetRule {
+	switch v := value.(type) {
+	case string:
+		// severity override of an inherited rule
+		return rulesetRule{severity: v}
+	case bool:
+		if v {
+			return rulesetRule{severity: "on"}
+		}
+		return rulesetRule{severity: "off"}
+	case map[interface{}]interface{}:
+		definition := make(map[interface{}]interface{}, len(v))
+		severity := defaultRuleSeverity
+		for key, item := range v {
+			if key == "severity" {
+				severity = normalizeRuleSeverity(item)
+				continue
+			}
+			definition[key] = item
+		}
+		return rulesetRule{severity: severity, definition: definition}
+	}
+	return rulesetRule{definition: value}
+}
+
+func normalizeRuleSeverity(value interface{}) string {
+	if n, ok := value.(int); ok && n >= 0 && n < len(numericRuleSeverities) {
+		return numericRuleSeverities[n]
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedRuleNames(rules map[string]rulesetRule) []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffStrings returns items of a which are not in b
+func diffStrings(a []string, b []string) []string {
+	result := make([]string, 0)
+	for _, item := range a {
+		found := false
+		for _, other := range b {
+			if item == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+	return result
+}
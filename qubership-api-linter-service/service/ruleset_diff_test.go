@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+func makeTestRuleset(id string, fileName string, data string) entity.RulesetWithData {
+	return entity.RulesetWithData{
+		Ruleset: entity.Ruleset{Id: id, Name: id, FileName: fileName, Linter: view.SpectralLinter},
+		Data:    []byte(data),
+	}
+}
+
+func TestMakeRulesetDiff(t *testing.T) {
+	base := makeTestRuleset("base", "ruleset.yaml", `
+extends: [spectral:oas, ./common.yaml]
+rules:
+  operation-description: warn
+  info-contact: false
+  path-casing:
+    severity: error
+    given: $.paths
+    then:
+      function: pattern
+      functionOptions:
+        match: ^[a-z/{}-]+$
+  tag-description:
+    given: $.tags[*]
+    then:
+      field: description
+      function: truthy
+  removed-rule:
+    severity: 1
+    given: $.info
+    then:
+      function: truthy
+`)
+	target := makeTestRuleset("target", "ruleset.yaml", `
+extends: [[spectral:oas, all]]
+rules:
+  operation-description: error
+  info-contact: true
+  path-casing:
+    severity: 0
+    given: $.paths
+    then:
+      function: pattern
+      functionOptions:
+        match: ^[a-z/{}-]+$
+  tag-description:
+    given: $.tags[*]
+    then:
+      field: name
+      function: truthy
+  added-rule:
+    given: $.info
+    then:
+      function: truthy
+`)
+	diff, err := makeRulesetDiff(base, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDiffRules := func(kind string, expected []view.RulesetDiffRule, actual []view.RulesetDiffRule) {
+		t.Helper()
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected %+v, got %+v", kind, expected, actual)
+		}
+	}
+	assertDiffRules("added", []view.RulesetDiffRule{{Name: "added-rule", Severity: "warn"}}, diff.Added)
+	assertDiffRules("removed", []view.RulesetDiffRule{{Name: "removed-rule", Severity: "warn"}}, diff.Removed)
+	assertDiffRules("severity changed", []view.RulesetDiffRule{
+		{Name: "info-contact", Severity: "on", PreviousSeverity: "off"},
+		{Name: "operation-description", Severity: "error", PreviousSeverity: "warn"},
+	}, diff.SeverityChanged)
+	assertDiffRules("modified", []view.RulesetDiffRule{{Name: "tag-description", Severity: "warn"}}, diff.Modified)
+	if !reflect.DeepEqual([]string{"[spectral:oas all]"}, diff.ExtendsAdded) {
+		t.Errorf("extends added: got %v", diff.ExtendsAdded)
+	}
+	if !reflect.DeepEqual([]string{"spectral:oas", "./common.yaml"}, diff.ExtendsRemoved) {
+		t.Errorf("extends removed: got %v", diff.ExtendsRemoved)
+	}
+}
+
+func TestMakeRulesetDiff_GraphqlRuleList(t *testing.T) {
+	base := makeTestRuleset("base", "config.json", `{"rules": ["types-have-descriptions", "fields-have-descriptions"]}`)
+	target := makeTestRuleset("target", "config.json", `{"rules": ["types-have-descriptions", "enum-values-sorted-alphabetically"]}`)
+	diff, err := makeRulesetDiff(base, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]view.RulesetDiffRule{{Name: "enum-values-sorted-alphabetically"}}, diff.Added) {
+		t.Errorf("added: got %+v", diff.Added)
+	}
+	if !reflect.DeepEqual([]view.RulesetDiffRule{{Name: "fields-have-descriptions"}}, diff.Removed) {
+		t.Errorf("removed: got %+v", diff.Removed)
+	}
+	if len(diff.SeverityChanged) != 0 || len(diff.Modified) != 0 {
+		t.Errorf("expected no changed rules, got %+v, %+v", diff.SeverityChanged, diff.Modified)
+	}
+}
+
+func TestMakeRulesetDiff_NotSupported(t *testing.T) {
+	valid := makeTestRuleset("valid", "ruleset.yaml", "rules: {}")
+	tests := []struct {
+		name    string
+		ruleset entity.RulesetWithData
+	}{
+		{"js ruleset", makeTestRuleset("js", "ruleset.js", "export default {}")},
+		{"invalid yaml", makeTestRuleset("invalid", "ruleset.yaml", "rules: [")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, pair := range [][2]entity.RulesetWithData{{test.ruleset, valid}, {valid, test.ruleset}} {
+				_, err := makeRulesetDiff(pair[0], pair[1])
+				var customErr *exception.CustomError
+				if !errors.As(err, &customErr) || customErr.Code != exception.RulesetDiffNotSupported {
+					t.Errorf("expected %s error, got %v", exception.RulesetDiffNotSupported, err)
+				}
+			}
+		})
+	}
+}
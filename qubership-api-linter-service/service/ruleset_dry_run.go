@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+const dryRunDownloadConcurrency = 5
+
+// RulesetDryRunService lints a published version with a candidate ruleset without activating it or storing the results
+type RulesetDryRunService interface {
+	DryRunRuleset(ctx context.Context, rulesetId string, req view.RulesetDryRunRequest) (*view.RulesetDryRunResult, error)
+}
+
+func NewRulesetDryRunService(rulesetRepository repository.RulesetRepository, apihubClient client.ApihubClient, linterRegistry LinterRegistry,
+	rulesetMaterializer RulesetMaterializer, ruleCatalogService RuleCatalogService, lintIgnoreExtension string) RulesetDryRunService {
+	return &rulesetDryRunServiceImpl{
+		rulesetRepository:   rulesetRepository,
+		apihubClient:        apihubClient,
+		linterRegistry:      linterRegistry,
+		rulesetMaterializer: rulesetMaterializer,
+		ruleCatalogService:  ruleCatalogService,
+		lintIgnoreExtension: lintIgnoreExtension,
+	}
+}
+
+type rulesetDryRunServiceImpl struct {
+	rulesetRepository   repository.RulesetRepository
+	apihubClient        client.ApihubClient
+	linterRegistry      LinterRegistry
+	rulesetMaterializer RulesetMaterializer
+	ruleCatalogService  RuleCatalogService
+	lintIgnoreExtension string
+}
+
+func (r rulesetDryRunServiceImpl) DryRunRuleset(ctx context.Context, rulesetId string, req view.RulesetDryRunRequest) (*view.RulesetDryRunResult, error) {
+	start := time.Now()
+
+	rs, err := r.rulesetRepository.GetRulesetWithData(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+	linter, err := r.linterRegistry.GetLinter(rs.Linter)
+	if err != nil || !linter.SupportsApiType(rs.ApiType) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.LinterNotSupportedForApiType,
+			Message: exception.LinterNotSupportedForApiTypeMsg,
+			Params:  map[string]interface{}{"linter": rs.Linter, "type": rs.ApiType},
+		}
+	}
+
+	docs, err := r.apihubClient.GetVersionDocuments(ctx, req.PackageId, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if docs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "version", "id": fmt.Sprintf("%s@%s", req.PackageId, req.Version)},
+		}
+	}
+
+	result := &view.RulesetDryRunResult{
+		Ruleset:   entity.MakeRulesetView(rs.Ruleset),
+		PackageId: req.PackageId,
+		Version:   req.Version,
+		Documents: make([]view.RulesetDryRunDocument, 0),
+	}
+	for _, doc := range docs.Documents {
+		// documents of other api types are not linted with the ruleset
+		if doc.Type != rs.ApiType {
+			continue
+		}
+		result.Documents = append(result.Documents, view.RulesetDryRunDocument{
+			ValidationDocument: view.ValidationDocument{
+				Status:       view.StatusSuccess,
+				Slug:         doc.Slug,
+				ApiType:      doc.Type,
+				DocumentName: doc.Filename,
+				RulesetId:    rs.Id,
+			},
+		})
+	}
+
+	downloadSem := utils.NewSemaphore(dryRunDownloadConcurrency)
+	wg := sync.WaitGroup{}
+	for i := range result.Documents {
+		doc := &result.Documents[i]
+		wg.Add(1)
+		utils.SafeAsync(func() {
+			defer wg.Done()
+			downloadSem.Acquire()
+			data, err := r.apihubClient.GetDocumentRawData(ctx, req.PackageId, req.Version, doc.Slug)
+			downloadSem.Release()
+			if err == nil && len(data) == 0 {
+				err = fmt.Errorf("document data is empty")
+			}
+			if err == nil {
+				doc.Issues, doc.IssuesSummary, err = lintDocumentData(ctx, r.rulesetMaterializer, linter, r.lintIgnoreExtension, doc.DocumentName, data, *rs)
+			}
+			if err == nil {
+				r.ruleCatalogService.AddRuleDescriptions(ctx, rs.Id, doc.Issues)
+			}
+			if err != nil {
+				doc.Status = view.StatusError
+				doc.Details = err.Error()
+			}
+		})
+	}
+	wg.Wait()
+
+	log.Infof("Dry run of ruleset %s (id = %s) for %s@%s finished, %d document(s) linted. Processing time = %dms",
+		rs.Name, rs.Id, req.PackageId, req.Version, len(result.Documents), time.Since(start).Milliseconds())
+	return result, nil
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultImpactSampleSize = 20
+	// the report is built by the instance which received the request, so it's not updated anymore if the instance is restarted
+	impactReportStaleTimeout = time.Minute * 30
+)
+
+// RulesetImpactService compares issue counts of a candidate ruleset with the rulesets currently used by packages
+type RulesetImpactService interface {
+	StartImpactReport(ctx context.Context, rulesetId string, req view.RulesetImpactRequest) (*view.RulesetImpactReport, error)
+	GetImpactReport(ctx context.Context, rulesetId string, reportId string) (*view.RulesetImpactReport, error)
+}
+
+func NewRulesetImpactService(impactRepository repository.RulesetImpactRepository, rulesetRepository repository.RulesetRepository,
+	versionResultRepository repository.VersionResultRepository, lintResultRepository repository.LintResultRepository,
+	linterSelectorService LinterSelectorService, linterRegistry LinterRegistry, rulesetMaterializer RulesetMaterializer,
+	apihubClient client.ApihubClient, lintIgnoreExtension string) RulesetImpactService {
+	return &rulesetImpactServiceImpl{
+		impactRepository:        impactRepository,
+		rulesetRepository:       rulesetRepository,
+		versionResultRepository: versionResultRepository,
+		lintResultRepository:    lintResultRepository,
+		linterSelectorService:   linterSelectorService,
+		linterRegistry:          linterRegistry,
+		rulesetMaterializer:     rulesetMaterializer,
+		apihubClient:            apihubClient,
+		lintIgnoreExtension:     lintIgnoreExtension,
+	}
+}
+
+type rulesetImpactServiceImpl struct {
+	impactRepository        repository.RulesetImpactRepository
+	rulesetRepository       repository.RulesetRepository
+	versionResultRepository repository.VersionResultRepository
+	lintResultRepository    repository.LintResultRepository
+	linterSelectorService   LinterSelectorService
+	linterRegistry          LinterRegistry
+	rulesetMaterializer     RulesetMaterializer
+	apihubClient            client.ApihubClient
+	lintIgnoreExtension     string
+}
+
+type impactRuleset struct {
+	linter  LinterExecutor
+	ruleset entity.RulesetWithData
+}
+
+func (r rulesetImpactServiceImpl) StartImpactReport(ctx context.Context, rulesetId string, req view.RulesetImpactRequest) (*view.RulesetImpactReport, error) {
+	rs, err := r.rulesetRepository.GetRulesetWithData(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset", "id": rulesetId},
+		}
+	}
+	linter, err := r.linterRegistry.GetLinter(rs.Linter)
+	if err != nil || !linter.SupportsApiType(rs.ApiType) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.LinterNotSupportedForApiType,
+			Message: exception.LinterNotSupportedForApiTypeMsg,
+			Params:  map[string]interface{}{"linter": rs.Linter, "type": rs.ApiType},
+		}
+	}
+
+	sampleSize := req.SampleSize
+	if req.AllPackages {
+		sampleSize = 0
+	} else if sampleSize <= 0 {
+		sampleSize = defaultImpactSampleSize
+	}
+
+	now := time.Now()
+	ent := entity.RulesetImpactReport{
+		Id:        uuid.NewString(),
+		RulesetId: rulesetId,
+		Status:    view.VersionStatusInProgress,
+		CreatedAt: now,
+		CreatedBy: secctx.GetUserId(ctx),
+		UpdatedAt: now,
+		Packages:  make([]view.RulesetImpactPackage, 0),
+	}
+	err = r.impactRepository.SaveImpactReport(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.SafeAsync(func() {
+		r.buildImpactReport(ent, impactRuleset{linter: linter, ruleset: *rs}, sampleSize)
+	})
+
+	result := entity.MakeRulesetImpactReportView(ent)
+	return &result, nil
+}
+
+func (r rulesetImpactServiceImpl) GetImpactReport(ctx context.Context, rulesetId string, reportId string) (*view.RulesetImpactReport, error) {
+	ent, err := r.impactRepository.GetImpactReport(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.RulesetId != rulesetId {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "ruleset impact report", "id": reportId},
+		}
+	}
+	if ent.Status == view.VersionStatusInProgress && time.Since(ent.UpdatedAt) > impactReportStaleTimeout {
+		ent.Status = view.VersionStatusError
+		ent.Details = "report processing was interrupted"
+	}
+	result := entity.MakeRulesetImpactReportView(*ent)
+	return &result, nil
+}
+
+func (r rulesetImpactServiceImpl) buildImpactReport(report entity.RulesetImpactReport, candidate impactRuleset, sampleSize int) {
+	start := time.Now()
+	ctx := secctx.MakeSysadminContext(context.Background())
+
+	versions, err := r.versionResultRepository.GetLatestLintedVersions(ctx, candidate.ruleset.ApiType, sampleSize)
+	if err != nil {
+		report.Status = view.VersionStatusError
+		report.Details = fmt.Sprintf("failed to get linted versions: %s", err)
+		r.updateImpactReport(ctx, report)
+		return
+	}
+	report.PackagesTotal = len(versions)
+	r.updateImpactReport(ctx, report)
+
+	for _, ver := range versions {
+		report.Packages = append(report.Packages, r.comparePackage(ctx, ver, candidate))
+		report.PackagesProcessed++
+		r.updateImpactReport(ctx, report)
+	}
+
+	report.Status = view.VersionStatusSuccess
+	r.updateImpactReport(ctx, report)
+	log.Infof("Impact report %s for ruleset %s (id = %s) finished, %d package(s) processed. Processing time = %dms",
+		report.Id, candidate.ruleset.Name, candidate.ruleset.Id, report.PackagesProcessed, time.Since(start).Milliseconds())
+}
+
+func (r rulesetImpactServiceImpl) updateImpactReport(ctx context.Context, report entity.RulesetImpactReport) {
+	report.UpdatedAt = time.Now()
+	err := r.impactRepository.UpdateImpactReport(ctx, report)
+	if err != nil {
+		log.Errorf("Failed to update ruleset impact report %s: %s", report.Id, err)
+	}
+}
+
+func (r rulesetImpactServiceImpl) comparePackage(ctx context.Context, ver entity.LintedVersion, candidate impactRuleset) view.RulesetImpactPackage {
+	apiType := candidate.ruleset.ApiType
+	result := view.RulesetImpactPackage{
+		PackageId: ver.PackageId,
+		Version:   fmt.Sprintf("%s@%d", ver.Version, ver.Revision),
+	}
+
+	current, err := r.getCurrentRuleset(ctx, ver.PackageId, apiType)
+	if err != nil {
+		result.Details = err.Error()
+		return result
+	}
+	result.CurrentRulesetId = current.ruleset.Id
+
+	_, docs, err := r.versionResultRepository.GetVersionAndDocsSummary(ctx, ver.PackageId, ver.Version, ver.Revision)
+	if err != nil {
+		result.Details = fmt.Sprintf("failed to get linted documents: %s", err)
+		return result
+	}
+	for _, doc := range docs {
+		if doc.SpecificationType != apiType {
+			continue
+		}
+		result.DocumentsCount++
+		var data []byte
+		currentSummary, err := r.getDocumentSummary(ctx, doc, *current, &data)
+		if err != nil {
+			log.Debugf("Impact report: failed to get summary of doc %s in %s@%s: %s", doc.Slug, ver.PackageId, result.Version, err)
+			result.FailedDocuments++
+			continue
+		}
+		candidateSummary, err := r.getDocumentSummary(ctx, doc, candidate, &data)
+		if err != nil {
+			log.Debugf("Impact report: failed to lint doc %s in %s@%s with candidate ruleset: %s", doc.Slug, ver.PackageId, result.Version, err)
+			result.FailedDocuments++
+			continue
+		}
+		result.Current = sumIssues(result.Current, *currentSummary, 1)
+		result.Candidate = sumIssues(result.Candidate, *candidateSummary, 1)
+	}
+	result.Delta = sumIssues(result.Candidate, result.Current, -1)
+	return result
+}
+
+func (r rulesetImpactServiceImpl) getCurrentRuleset(ctx context.Context, packageId string, apiType view.ApiType) (*impactRuleset, error) {
+	linterName, rulesetId, err := r.linterSelectorService.SelectLinterAndRuleset(ctx, packageId, apiType)
+	if err != nil {
+		return nil, err
+	}
+	if linterName == view.UnknownLinter || rulesetId == "" {
+		return nil, fmt.Errorf("no ruleset is currently used for API type %s", apiType)
+	}
+	linter, err := r.linterRegistry.GetLinter(linterName)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := r.rulesetRepository.GetRulesetWithData(ctx, rulesetId)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, fmt.Errorf("ruleset with id %s not found", rulesetId)
+	}
+	return &impactRuleset{linter: linter, ruleset: *rs}, nil
+}
+
+// getDocumentSummary uses the stored lint result if the document was already linted with the ruleset, otherwise the document is downloaded once and linted
+func (r rulesetImpactServiceImpl) getDocumentSummary(ctx context.Context, doc entity.LintedDocument, rs impactRuleset, data *[]byte) (*view.IssuesSummary, error) {
+	if doc.DataHash != "" {
+		stored, err := r.lintResultRepository.GetLintResultSummary(ctx, doc.DataHash, rs.ruleset.Id)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			return makeNotNilSummary(rs.linter.ParseSummary(stored.Summary))
+		}
+	}
+	if *data == nil {
+		docData, err := r.apihubClient.GetDocumentRawData(ctx, doc.PackageId, fmt.Sprintf("%s@%d", doc.Version, doc.Revision), doc.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if len(docData) == 0 {
+			return nil, fmt.Errorf("document data is empty")
+		}
+		*data = docData
+	}
+	_, summary, err := lintDocumentData(ctx, r.rulesetMaterializer, rs.linter, r.lintIgnoreExtension, doc.FileId, *data, rs.ruleset)
+	return makeNotNilSummary(summary, err)
+}
+
+func makeNotNilSummary(summary *view.IssuesSummary, err error) (*view.IssuesSummary, error) {
+	if err == nil && summary == nil {
+		err = fmt.Errorf("failed to calculate result summary")
+	}
+	return summary, err
+}
+
+func sumIssues(a view.IssuesSummary, b view.IssuesSummary, sign int) view.IssuesSummary {
+	return view.IssuesSummary{
+		Error:   a.Error + sign*b.Error,
+		Warning: a.Warning + sign*b.Warning,
+		Info:    a.Info + sign*b.Info,
+		Hint:    a.Hint + sign*b.Hint,
+	}
+}
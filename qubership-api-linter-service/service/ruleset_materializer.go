@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"gopkg.in/yaml.v2"
+)
+
+const rulesetFunctionsDir = "functions"
+
+// rulesetDir is the subdirectory for the ruleset files, so they can't overwrite the linted document or be overwritten by it
+const rulesetDir = "ruleset"
+
+// RulesetMaterializer writes a stored ruleset together with its parents and custom functions to a directory
+type RulesetMaterializer interface {
+	// Materialize writes the ruleset to the subdirectory of dir and returns the path of the ruleset file to pass to the linter
+	Materialize(ctx context.Context, ruleset entity.RulesetWithData, dir string) (string, error)
+	// MaterializeNew is the same as Materialize for a ruleset which is not stored yet, so its files are passed explicitly
+	MaterializeNew(ctx context.Context, ruleset entity.RulesetWithData, files []entity.RulesetFile, dir string) (string, error)
+	// CheckDependencies validates the whole graph of the ruleset parents
+	CheckDependencies(ctx context.Context, ruleset entity.Ruleset) error
+}
+
+func NewRulesetMaterializer(rulesetRepository repository.RulesetRepository) RulesetMaterializer {
+	return &rulesetMaterializerImpl{rulesetRepository: rulesetRepository}
+}
+
+type rulesetMaterializerImpl struct {
+	rulesetRepository repository.RulesetRepository
+}
+
+func (m rulesetMaterializerImpl) Materialize(ctx context.Context, ruleset entity.RulesetWithData, dir string) (string, error) {
+	err := m.CheckDependencies(ctx, ruleset.Ruleset)
+	if err != nil {
+		return "", err
+	}
+	rootDir := filepath.Join(dir, rulesetDir)
+	return m.writeRuleset(ctx, ruleset, nil, rootDir, rootDir, make(map[string]string))
+}
+
+func (m rulesetMaterializerImpl) MaterializeNew(ctx context.Context, ruleset entity.RulesetWithData, files []entity.RulesetFile, dir string) (string, error) {
+	err := m.CheckDependencies(ctx, ruleset.Ruleset)
+	if err != nil {
+		return "", err
+	}
+	if files == nil {
+		files = make([]entity.RulesetFile, 0)
+	}
+	rootDir := filepath.Join(dir, rulesetDir)
+	return m.writeRuleset(ctx, ruleset, files, rootDir, rootDir, make(map[string]string))
+}
+
+func (m rulesetMaterializerImpl) CheckDependencies(ctx context.Context, ruleset entity.Ruleset) error {
+	return m.checkParents(ctx, ruleset, []string{ruleset.Id}, make(map[string]bool))
+}
+
+func (m rulesetMaterializerImpl) checkParents(ctx context.Context, ruleset entity.Ruleset, stack []string, checked map[string]bool) error {
+	if len(ruleset.Parents) == 0 {
+		return nil
+	}
+	err := checkCompositionSupported(ruleset.Linter, ruleset.FileName)
+	if err != nil {
+		return err
+	}
+	for _, parentId := range ruleset.Parents {
+		for i, id := range stack {
+			if id == parentId {
+				return &exception.CustomError{
+					Status:  http.StatusBadRequest,
+					Code:    exception.RulesetDependencyCycle,
+					Message: exception.RulesetDependencyCycleMsg,
+					Params:  map[string]interface{}{"cycle": strings.Join(append(stack[i:], parentId), " -> ")},
+				}
+			}
+		}
+		if checked[parentId] {
+			continue
+		}
+		parent, err := m.rulesetRepository.GetRulesetById(ctx, parentId)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.RulesetParentNotFound,
+				Message: exception.RulesetParentNotFoundMsg,
+				Params:  map[string]interface{}{"parent": parentId, "type": ruleset.ApiType},
+			}
+		}
+		if parent.Linter != ruleset.Linter {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.RulesetParentLinterMismatch,
+				Message: exception.RulesetParentLinterMismatchMsg,
+				Params:  map[string]interface{}{"parent": parentId, "parentLinter": parent.Linter, "linter": ruleset.Linter},
+			}
+		}
+		parentStack := append(append(make([]string, 0, len(stack)+1), stack...), parentId)
+		err = m.checkParents(ctx, *parent, parentStack, checked)
+		if err != nil {
+			return err
+		}
+		checked[parentId] = true
+	}
+	return nil
+}
+
+// writeRuleset writes parents to <rootDir>/parents/<id> and adds them to extends of the ruleset, so the linter resolves them by relative paths.
+// Files of the ruleset are loaded from the DB if nil.
+func (m rulesetMaterializerImpl) writeRuleset(ctx context.Context, ruleset entity.RulesetWithData, files []entity.RulesetFile, rootDir string, dir string, written map[string]string) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("error creating ruleset directory: %s", err)
+	}
+
+	parentPaths := make([]string, 0, len(ruleset.Parents))
+	for _, parentId := range ruleset.Parents {
+		parentPath, exists := written[parentId]
+		if !exists {
+			parent, err := m.rulesetRepository.GetRulesetWithData(ctx, parentId)
+			if err != nil {
+				return "", err
+			}
+			if parent == nil {
+				return "", fmt.Errorf("parent ruleset with id %s not found", parentId)
+			}
+			parentPath, err = m.writeRuleset(ctx, *parent, nil, rootDir, filepath.Join(rootDir, "parents", parentId), written)
+			if err != nil {
+				return "", err
+			}
+		}
+		relPath, err := filepath.Rel(dir, parentPath)
+		if err != nil {
+			return "", err
+		}
+		parentPaths = append(parentPaths, "./"+filepath.ToSlash(relPath))
+	}
+
+	if files == nil && ruleset.Id != "" {
+		var err error
+		files, err = m.rulesetRepository.GetRulesetFiles(ctx, ruleset.Id)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, file := range files {
+		err := writeRulesetFile(dir, file.Path, file.Data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Some linters (e.g. Spectral) have a problem with some characters is file names, so generating a safe one.
+	rulesetPath := filepath.Join(dir, "ruleset"+filepath.Ext(ruleset.FileName))
+	data := ruleset.Data
+	if len(parentPaths) > 0 {
+		var err error
+		data, err = addRulesetExtends(ruleset.Data, parentPaths)
+		if err != nil {
+			return "", fmt.Errorf("error adding parents to ruleset %s: %s", ruleset.Id, err)
+		}
+		// JSON is a subset of YAML, so the modified ruleset is always written as YAML
+		rulesetPath = filepath.Join(dir, "ruleset.yaml")
+	}
+	if err := os.WriteFile(rulesetPath, data, 0600); err != nil {
+		return "", fmt.Errorf("error writing ruleset file: %s", err)
+	}
+	if ruleset.Id != "" {
+		written[ruleset.Id] = rulesetPath
+	}
+	return rulesetPath, nil
+}
+
+func writeRulesetFile(dir string, path string, data []byte) error {
+	filePath := filepath.Join(dir, filepath.FromSlash(path))
+	if !strings.HasPrefix(filePath, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return fmt.Errorf("ruleset file path %s is outside of the ruleset directory", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return fmt.Errorf("error creating ruleset file directory: %s", err)
+	}
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("error writing ruleset file %s: %s", path, err)
+	}
+	return nil
+}
+
+// addRulesetExtends puts the parents before the own extends of the ruleset
+func addRulesetExtends(data []byte, parentPaths []string) ([]byte, error) {
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	extends := make([]interface{}, 0, len(parentPaths))
+	for _, p := range parentPaths {
+		extends = append(extends, p)
+	}
+	found := false
+	for i, item := range doc {
+		if item.Key != "extends" {
+			continue
+		}
+		switch v := item.Value.(type) {
+		case nil:
+		case []interface{}:
+			if isExtendsWithSeverity(v) {
+				extends = append(extends, v)
+			} else {
+				extends = append(extends, v...)
+			}
+		default:
+			extends = append(extends, v)
+		}
+		doc[i].Value = extends
+		found = true
+	}
+	if !found {
+		doc = append(yaml.MapSlice{{Key: "extends", Value: extends}}, doc...)
+	}
+	return yaml.Marshal(doc)
+}
+
+// isExtendsWithSeverity checks if the value is a single [ruleset, severity] pair rather than a list of rulesets
+func isExtendsWithSeverity(v []interface{}) bool {
+	if len(v) != 2 {
+		return false
+	}
+	if _, ok := v[0].(string); !ok {
+		return false
+	}
+	severity, ok := v[1].(string)
+	return ok && (severity == "off" || severity == "recommended" || severity == "all")
+}
+
+func checkCompositionSupported(linter view.Linter, rulesetFileName string) error {
+	if linter != view.SpectralLinter && linter != view.VacuumLinter {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RulesetCompositionNotSupported,
+			Message: exception.RulesetCompositionNotSupportedMsg,
+			Params:  map[string]interface{}{"reason": fmt.Sprintf("linter %s", linter)},
+		}
+	}
+	switch strings.ToLower(filepath.Ext(rulesetFileName)) {
+	case ".yaml", ".yml", ".json":
+		return nil
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.RulesetCompositionNotSupported,
+		Message: exception.RulesetCompositionNotSupportedMsg,
+		Params:  map[string]interface{}{"reason": fmt.Sprintf("ruleset file %s, only YAML and JSON rulesets can extend other rulesets", rulesetFileName)},
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import "testing"
+
+func TestIsExtendsWithSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []interface{}
+		expected bool
+	}{
+		{"empty", []interface{}{}, false},
+		{"single ruleset", []interface{}{"spectral:oas"}, false},
+		{"ruleset with off", []interface{}{"spectral:oas", "off"}, true},
+		{"ruleset with recommended", []interface{}{"spectral:oas", "recommended"}, true},
+		{"ruleset with all", []interface{}{"spectral:oas", "all"}, true},
+		{"two rulesets", []interface{}{"spectral:oas", "./common.yaml"}, false},
+		{"unquoted off", []interface{}{"spectral:oas", false}, false},
+		{"nested pair", []interface{}{[]interface{}{"spectral:oas", "off"}, "all"}, false},
+		{"three items", []interface{}{"spectral:oas", "all", "off"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := isExtendsWithSeverity(test.value); actual != test.expected {
+				t.Errorf("isExtendsWithSeverity(%v) = %v, expected %v", test.value, actual, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v2"
+)
+
+const maxRulesetProblems = 20
+
+// sample documents are linted on ruleset upload to make sure the linter is able to load the ruleset
+var rulesetSampleDocuments = map[view.ApiType]struct {
+	fileName string
+	data     string
+}{
+	view.OpenAPI20Type: {"sample.yaml", `swagger: "2.0"
+info:
+  title: Sample
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          description: OK
+`},
+	view.OpenAPI30Type: {"sample.yaml", `openapi: 3.0.3
+info:
+  title: Sample
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          description: OK
+`},
+	view.OpenAPI31Type: {"sample.yaml", `openapi: 3.1.0
+info:
+  title: Sample
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          description: OK
+`},
+	view.AsyncAPI2Type: {"sample.yaml", `asyncapi: 2.6.0
+info:
+  title: Sample
+  version: 1.0.0
+channels:
+  items:
+    subscribe:
+      message:
+        payload:
+          type: string
+`},
+	view.AsyncAPI3Type: {"sample.yaml", `asyncapi: 3.0.0
+info:
+  title: Sample
+  version: 1.0.0
+channels:
+  items:
+    address: items
+    messages:
+      item:
+        payload:
+          type: string
+operations:
+  receiveItem:
+    action: receive
+    channel:
+      $ref: '#/channels/items'
+`},
+	view.GraphQLType: {"sample.graphql", `type Query {
+  items: [String]
+}
+`},
+}
+
+// validateRuleset lints the sample document of the ruleset api type with the new ruleset and returns the problems found
+func validateRuleset(ctx context.Context, rulesetMaterializer RulesetMaterializer, linter LinterExecutor, ruleset entity.RulesetWithData, files []entity.RulesetFile) error {
+	var problems []string
+	switch strings.ToLower(filepath.Ext(ruleset.FileName)) {
+	case ".yaml", ".yml", ".json":
+		var doc yaml.MapSlice
+		if err := yaml.Unmarshal(ruleset.Data, &doc); err != nil {
+			problems = append(problems, fmt.Sprintf("ruleset file is not a valid YAML/JSON: %s", err))
+		}
+	}
+
+	sample, exists := rulesetSampleDocuments[ruleset.ApiType]
+	if len(problems) == 0 && exists {
+		var err error
+		problems, err = lintSampleDocument(ctx, rulesetMaterializer, linter, ruleset, files, sample.fileName, []byte(sample.data))
+		if err != nil {
+			return err
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > maxRulesetProblems {
+		problems = append(problems[:maxRulesetProblems], fmt.Sprintf("%d more problem(s)", len(problems)-maxRulesetProblems))
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.RulesetValidationFailed,
+		Message: exception.RulesetValidationFailedMsg,
+		Params:  map[string]interface{}{"name": ruleset.FileName, "linter": ruleset.Linter, "problems": problems},
+	}
+}
+
+// lintSampleDocument returns the problems reported by the linter which rejected the ruleset,
+// the error is returned if the validation itself failed
+func lintSampleDocument(ctx context.Context, rulesetMaterializer RulesetMaterializer, linter LinterExecutor, ruleset entity.RulesetWithData,
+	files []entity.RulesetFile, docFileName string, docData []byte) ([]string, error) {
+	tempDir := filepath.Join(os.TempDir(), uuid.NewString())
+	if err := os.MkdirAll(tempDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create temp directory for ruleset validation: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	rulesetPath, err := rulesetMaterializer.MaterializeNew(ctx, ruleset, files, tempDir)
+	if err != nil {
+		return nil, err
+	}
+	docPath := filepath.Join(tempDir, "file"+filepath.Ext(docFileName))
+	if err := os.WriteFile(docPath, docData, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write sample document for ruleset validation: %w", err)
+	}
+
+	report, _, err := linter.LintLocalDoc(docPath, rulesetPath)
+	if err != nil {
+		var rejectedErr *LinterRejectedError
+		if !errors.As(err, &rejectedErr) {
+			return nil, fmt.Errorf("failed to lint sample document for ruleset validation: %w", err)
+		}
+		var problems []string
+		for _, line := range strings.Split(rejectedErr.Details, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				problems = append(problems, line)
+			}
+		}
+		return problems, nil
+	}
+	if _, err = linter.GetIssues(report); err != nil {
+		return nil, fmt.Errorf("failed to parse %s report of ruleset validation: %w", linter.GetLinter(), err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+// SarifExportService converts lint results into SARIF 2.1.0 logs for CI and code scanning tools.
+// Waived issues are reported as results with accepted external suppressions.
+type SarifExportService interface {
+	// GetDocumentSarif returns nil if the document is not linted
+	GetDocumentSarif(ctx context.Context, packageId string, version string, slug string) (*view.SarifLog, error)
+	// GetVersionSarif returns the log with all linted documents of the version, nil if the version is not linted
+	GetVersionSarif(ctx context.Context, packageId string, version string) (*view.SarifLog, error)
+}
+
+func NewSarifExportService(versionResultRepository repository.VersionResultRepository, lintResultRepository repository.LintResultRepository,
+	rulesetRepository repository.RulesetRepository, linterRegistry LinterRegistry, ruleCatalogService RuleCatalogService,
+	waiverService WaiverService, apihubClient client.ApihubClient) SarifExportService {
+	return &sarifExportServiceImpl{
+		versionResultRepository: versionResultRepository,
+		lintResultRepository:    lintResultRepository,
+		rulesetRepository:       rulesetRepository,
+		linterRegistry:          linterRegistry,
+		ruleCatalogService:      ruleCatalogService,
+		waiverService:           waiverService,
+		apihubClient:            apihubClient,
+	}
+}
+
+type sarifExportServiceImpl struct {
+	versionResultRepository repository.VersionResultRepository
+	lintResultRepository    repository.LintResultRepository
+	rulesetRepository       repository.RulesetRepository
+	linterRegistry          LinterRegistry
+	ruleCatalogService      RuleCatalogService
+	waiverService           WaiverService
+	apihubClient            client.ApihubClient
+}
+
+func (s sarifExportServiceImpl) GetDocumentSarif(ctx context.Context, packageId string, version string, slug string) (*view.SarifLog, error) {
+	ver, rev, err := resolveVersionRevision(ctx, s.apihubClient, packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	lintedDocument, err := s.versionResultRepository.GetLintedDocument(ctx, packageId, ver, rev, slug)
+	if err != nil {
+		return nil, err
+	}
+	if lintedDocument == nil {
+		return nil, nil
+	}
+	return s.buildSarifLog(ctx, packageId, ver, []entity.LintedDocument{*lintedDocument})
+}
+
+func (s sarifExportServiceImpl) GetVersionSarif(ctx context.Context, packageId string, version string) (*view.SarifLog, error) {
+	ver, rev, err := resolveVersionRevision(ctx, s.apihubClient, packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	lintedVer, lintedDocs, err := s.versionResultRepository.GetVersionAndDocsSummary(ctx, packageId, ver, rev)
+	if err != nil {
+		return nil, err
+	}
+	if lintedVer == nil {
+		return nil, nil
+	}
+	return s.buildSarifLog(ctx, packageId, ver, lintedDocs)
+}
+
+// buildSarifLog makes one run per ruleset and linter version since a run has a single tool and rule set
+func (s sarifExportServiceImpl) buildSarifLog(ctx context.Context, packageId string, version string, docs []entity.LintedDocument) (*view.SarifLog, error) {
+	waivers, err := s.waiverService.GetVersionWaivers(ctx, packageId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &view.SarifLog{
+		Schema:  view.SarifSchema,
+		Version: view.SarifVersion,
+		Runs:    make([]view.SarifRun, 0),
+	}
+	builders := make(map[string]*sarifRunBuilder)
+	var order []string
+	rulesets := make(map[string]*entity.Ruleset)
+
+	for _, doc := range docs {
+		if doc.LintStatus != view.StatusSuccess && doc.LintStatus != view.StatusError {
+			continue
+		}
+		ruleset, ok := rulesets[doc.RulesetId]
+		if !ok {
+			ruleset, err = s.rulesetRepository.GetRulesetById(ctx, doc.RulesetId)
+			if err != nil {
+				return nil, err
+			}
+			if ruleset == nil {
+				return nil, fmt.Errorf("ruleset with id %s not found", doc.RulesetId)
+			}
+			rulesets[doc.RulesetId] = ruleset
+		}
+
+		var lintResult *entity.LintFileResult
+		linterVersion := ""
+		if doc.LintStatus == view.StatusSuccess {
+			lintResult, err = s.lintResultRepository.GetLintResult(ctx, doc.DataHash, doc.RulesetId)
+			if err != nil {
+				return nil, err
+			}
+			if lintResult == nil {
+				continue
+			}
+			linterVersion = lintResult.LinterVersion
+		}
+
+		key := doc.RulesetId + "@" + linterVersion
+		builder, ok := builders[key]
+		if !ok {
+			builder = s.newSarifRunBuilder(ctx, *ruleset, linterVersion)
+			builders[key] = builder
+			order = append(order, key)
+		}
+		artifactIdx := builder.addArtifact(doc)
+
+		if doc.LintStatus == view.StatusError {
+			builder.addExecutionError(doc, artifactIdx)
+			continue
+		}
+
+		linter, err := s.linterRegistry.GetLinter(ruleset.Linter)
+		if err != nil {
+			return nil, err
+		}
+		issues, err := linter.GetIssues(lintResult.Data)
+		if err != nil {
+			return nil, err
+		}
+		issues, waivedIssues := waivers.Apply(doc.Slug, issues)
+		for _, issue := range issues {
+			builder.addResult(issue, artifactIdx, nil)
+		}
+		for _, issue := range waivedIssues {
+			builder.addResult(issue, artifactIdx, &view.SarifSuppression{
+				Kind:          "external",
+				Status:        "accepted",
+				Justification: waivers.GetJustification(issue.WaiverId),
+			})
+		}
+	}
+
+	for _, key := range order {
+		result.Runs = append(result.Runs, builders[key].run)
+	}
+	return result, nil
+}
+
+type sarifRunBuilder struct {
+	run       view.SarifRun
+	ruleIndex map[string]int
+}
+
+func (s sarifExportServiceImpl) newSarifRunBuilder(ctx context.Context, ruleset entity.Ruleset, linterVersion string) *sarifRunBuilder {
+	builder := &sarifRunBuilder{
+		run: view.SarifRun{
+			Tool: view.SarifTool{
+				Driver: view.SarifToolComponent{
+					Name:    string(ruleset.Linter),
+					Version: linterVersion,
+					Rules:   make([]view.SarifReportingDescriptor, 0),
+				},
+			},
+			Invocations: []view.SarifInvocation{{ExecutionSuccessful: true}},
+			Artifacts:   make([]view.SarifArtifact, 0),
+			Results:     make([]view.SarifResult, 0),
+			Properties: map[string]interface{}{
+				"rulesetId":   ruleset.Id,
+				"rulesetName": ruleset.Name,
+			},
+		},
+		ruleIndex: make(map[string]int),
+	}
+	// rules which are not in the catalog are added on the first issue
+	catalog, err := s.ruleCatalogService.GetRuleCatalog(ctx, ruleset.Id)
+	if err != nil {
+		log.Debugf("Failed to get rule catalog of ruleset %s: %s", ruleset.Id, err)
+		return builder
+	}
+	for _, rule := range catalog.Rules {
+		builder.addRule(rule)
+	}
+	return builder
+}
+
+func (b *sarifRunBuilder) addRule(rule view.RuleDescription) int {
+	descriptor := view.SarifReportingDescriptor{
+		Id:      rule.Code,
+		HelpUri: rule.DocumentationUrl,
+	}
+	if rule.Description != "" {
+		descriptor.ShortDescription = &view.SarifMessage{Text: rule.Description}
+	}
+	switch rule.Severity {
+	case "":
+	case "off":
+		enabled := false
+		descriptor.DefaultConfiguration = &view.SarifReportingConfiguration{Enabled: &enabled}
+	default:
+		descriptor.DefaultConfiguration = &view.SarifReportingConfiguration{Level: toSarifLevel(rule.Severity)}
+	}
+	if rule.Source != "" {
+		descriptor.Properties = map[string]interface{}{"source": rule.Source}
+	}
+	idx := len(b.run.Tool.Driver.Rules)
+	b.run.Tool.Driver.Rules = append(b.run.Tool.Driver.Rules, descriptor)
+	b.ruleIndex[rule.Code] = idx
+	return idx
+}
+
+func (b *sarifRunBuilder) addArtifact(doc entity.LintedDocument) int {
+	idx := len(b.run.Artifacts)
+	b.run.Artifacts = append(b.run.Artifacts, view.SarifArtifact{
+		Location:   view.SarifArtifactLocation{Uri: doc.FileId, Index: idx},
+		Properties: map[string]interface{}{"slug": doc.Slug},
+	})
+	return idx
+}
+
+func (b *sarifRunBuilder) addExecutionError(doc entity.LintedDocument, artifactIdx int) {
+	invocation := &b.run.Invocations[0]
+	invocation.ExecutionSuccessful = false
+	invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, view.SarifNotification{
+		Level:   "error",
+		Message: view.SarifMessage{Text: doc.LintDetails},
+		Locations: []view.SarifLocation{{
+			PhysicalLocation: view.SarifPhysicalLocation{ArtifactLocation: b.run.Artifacts[artifactIdx].Location},
+		}},
+	})
+}
+
+func (b *sarifRunBuilder) addResult(issue view.ValidationIssue, artifactIdx int, suppression *view.SarifSuppression) {
+	ruleIdx, ok := b.ruleIndex[issue.Code]
+	if !ok {
+		ruleIdx = b.addRule(view.RuleDescription{Code: issue.Code})
+	}
+	location := view.SarifLocation{
+		PhysicalLocation: view.SarifPhysicalLocation{
+			ArtifactLocation: b.run.Artifacts[artifactIdx].Location,
+			Region:           toSarifRegion(issue.Range),
+		},
+	}
+	if len(issue.Path) > 0 {
+		location.LogicalLocations = []view.SarifLogicalLocation{{FullyQualifiedName: utils.MakeJsonPointer(issue.Path)}}
+	}
+	result := view.SarifResult{
+		RuleId:    issue.Code,
+		RuleIndex: ruleIdx,
+		Level:     toSarifLevel(issue.Severity),
+		Message:   view.SarifMessage{Text: issue.Message},
+		Locations: []view.SarifLocation{location},
+	}
+	if suppression != nil {
+		result.Suppressions = []view.SarifSuppression{*suppression}
+	}
+	b.run.Results = append(b.run.Results, result)
+}
+
+func toSarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning", "warn":
+		return "warning"
+	case "info":
+		return "note"
+	}
+	return "none"
+}
+
+// toSarifRegion converts zero-based linter range to one-based SARIF region
+func toSarifRegion(issueRange *view.IssueRange) *view.SarifRegion {
+	if issueRange == nil {
+		return nil
+	}
+	return &view.SarifRegion{
+		StartLine:   issueRange.Start.Line + 1,
+		StartColumn: issueRange.Start.Character + 1,
+		EndLine:     issueRange.End.Line + 1,
+		EndColumn:   issueRange.End.Character + 1,
+	}
+}
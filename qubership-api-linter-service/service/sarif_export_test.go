@@ -0,0 +1,59 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Netcracker/qubership-api-linter-service/view"
+)
+
+func TestToSarifRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		issueRange *view.IssueRange
+		expected   *view.SarifRegion
+	}{
+		{"no range", nil, nil},
+		{"document start", &view.IssueRange{}, &view.SarifRegion{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 1}},
+		{
+			"multiline",
+			&view.IssueRange{Start: view.IssuePosition{Line: 4, Character: 2}, End: view.IssuePosition{Line: 9, Character: 17}},
+			&view.SarifRegion{StartLine: 5, StartColumn: 3, EndLine: 10, EndColumn: 18},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := toSarifRegion(test.issueRange); !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("expected %+v, got %+v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSarifRunBuilderAddRule(t *testing.T) {
+	tests := []struct {
+		severity string
+		expected string
+	}{
+		{"", `{"id":"rule"}`},
+		{"error", `{"id":"rule","defaultConfiguration":{"level":"error"}}`},
+		{"warn", `{"id":"rule","defaultConfiguration":{"level":"warning"}}`},
+		{"info", `{"id":"rule","defaultConfiguration":{"level":"note"}}`},
+		{"hint", `{"id":"rule","defaultConfiguration":{"level":"none"}}`},
+		{"off", `{"id":"rule","defaultConfiguration":{"enabled":false}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.severity, func(t *testing.T) {
+			builder := &sarifRunBuilder{ruleIndex: make(map[string]int)}
+			idx := builder.addRule(view.RuleDescription{Code: "rule", Severity: test.severity})
+			actual, err := json.Marshal(builder.run.Tool.Driver.Rules[idx])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(actual) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
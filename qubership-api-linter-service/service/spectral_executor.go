@@ -3,11 +3,13 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/Netcracker/qubership-api-linter-service/utils"
 	"github.com/Netcracker/qubership-api-linter-service/view"
 	log "github.com/sirupsen/logrus"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -15,17 +17,12 @@ import (
 	"time"
 )
 
-type SpectralExecutor interface {
-	LintLocalDoc(docPath string, rulesetPath string) (string, int64, error)
-	GetLinterVersion() string
-}
-
-func NewSpectralExecutor(spectralBinPath string) (SpectralExecutor, error) {
+func NewSpectralExecutor(spectralBinPath string, concurrency int) (LinterExecutor, error) {
 	spectralVersion, err := detectSpectralVersion(spectralBinPath)
 	if err != nil {
 		return nil, err
 	}
-	return &spectralExecutorImpl{spectralBinPath: spectralBinPath, semaphore: utils.NewSemaphore(1), spectralVersion: spectralVersion}, nil
+	return &spectralExecutorImpl{spectralBinPath: spectralBinPath, semaphore: utils.NewSemaphore(concurrency), spectralVersion: spectralVersion}, nil
 }
 
 type spectralExecutorImpl struct {
@@ -34,7 +31,20 @@ type spectralExecutorImpl struct {
 	spectralVersion string
 }
 
-func (s *spectralExecutorImpl) LintLocalDoc(docPath string, rulesetPath string) (string, int64, error) {
+func (s *spectralExecutorImpl) GetLinter() view.Linter {
+	return view.SpectralLinter
+}
+
+func (s *spectralExecutorImpl) SupportsApiType(apiType view.ApiType) bool {
+	switch apiType {
+	case view.OpenAPI31Type, view.OpenAPI30Type, view.OpenAPI20Type, view.AsyncAPI2Type, view.AsyncAPI3Type:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *spectralExecutorImpl) LintLocalDoc(docPath string, rulesetPath string) ([]byte, int64, error) {
 	s.semaphore.Acquire()
 	defer s.semaphore.Release()
 
@@ -61,7 +71,8 @@ func (s *spectralExecutorImpl) LintLocalDoc(docPath string, rulesetPath string)
 	args = append(args, resultPath)
 
 	limit := time.Minute * 10
-	ctx, _ := context.WithDeadline(context.Background(), time.Now().Add(limit))
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(limit))
+	defer cancel()
 
 	cmd := exec.CommandContext(ctx, s.spectralBinPath, args...)
 	var out bytes.Buffer
@@ -91,20 +102,22 @@ func (s *spectralExecutorImpl) LintLocalDoc(docPath string, rulesetPath string)
 			if stderr.String() != "" {
 				errStr += " | stderr: " + stderr.String()
 			}
-			return "", calculationTime.Milliseconds(), errors.New(errStr)
+			return nil, calculationTime.Milliseconds(), errors.New(errStr)
 		}
 
 		//spectral process exits with status 1 if validation contains at least one error...
 		if err.Error() != "exit status 1" {
-			errStr := err.Error()
-			if stderr.String() != "" {
-				errStr += " | stderr: " + stderr.String()
-			}
-			return "", calculationTime.Milliseconds(), fmt.Errorf("failed to get Spectral report: %v", errStr)
+			return nil, calculationTime.Milliseconds(), makeLinterRunError("Spectral", err, stderr.String())
 		}
 	}
 
-	return resultPath, calculationTime.Milliseconds(), nil
+	result, err := os.ReadFile(resultPath)
+	if err != nil {
+		return nil, calculationTime.Milliseconds(), fmt.Errorf("error reading result file: %s", err)
+	}
+	log.Tracef("result file size is %d bytes", len(result))
+
+	return result, calculationTime.Milliseconds(), nil
 }
 
 func (s *spectralExecutorImpl) GetLinterVersion() string {
@@ -131,6 +144,75 @@ func detectSpectralVersion(spectralBinPath string) (string, error) {
 	return spectralVersion, nil
 }
 
+func (s *spectralExecutorImpl) GetIssues(report []byte) ([]view.ValidationIssue, error) {
+	var spectralOutput []view.SpectralOutputItem
+	err := json.Unmarshal(report, &spectralOutput)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]view.ValidationIssue, 0)
+	for _, item := range spectralOutput {
+		var path []string
+		if item.Path != nil {
+			path = item.Path
+		} else {
+			path = make([]string, 0)
+		}
+		issueRange := item.Range
+		issues = append(issues, view.ValidationIssue{
+			Path:     path,
+			Code:     item.Code,
+			Severity: view.ConvertSpectralSeverityToString(item.Severity),
+			Message:  item.Message,
+			Range:    &issueRange,
+		})
+	}
+	return issues, nil
+}
+
+func (s *spectralExecutorImpl) FilterReport(report []byte, keep func(issue view.ValidationIssue) bool) ([]byte, error) {
+	var items []json.RawMessage
+	err := json.Unmarshal(report, &items)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.GetIssues(report)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]json.RawMessage, 0, len(items))
+	for i, item := range items {
+		if keep(issues[i]) {
+			kept = append(kept, item)
+		}
+	}
+	return json.Marshal(kept)
+}
+
+func (s *spectralExecutorImpl) CalculateSummary(report []byte) (map[string]interface{}, error) {
+	var reportObj []interface{}
+	err := json.Unmarshal(report, &reportObj)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling result: %s", err)
+	}
+	summary := calculateSpectralSummary(reportObj)
+
+	sumJson, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling summary: %s", err)
+	}
+	var sumAsMap map[string]interface{}
+	err = json.Unmarshal(sumJson, &sumAsMap)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling summary: %s", err)
+	}
+	return sumAsMap, nil
+}
+
+func (s *spectralExecutorImpl) ParseSummary(summary map[string]interface{}) (*view.IssuesSummary, error) {
+	return makeIssuesSummary(summary)
+}
+
 func calculateSpectralSummary(report []interface{}) view.SpectralResultSummary {
 	summary := view.SpectralResultSummary{}
 	for _, resultObj := range report {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Netcracker/qubership-api-linter-service/client"
@@ -46,6 +47,18 @@ const (
 	LOG_LEVEL      = "LOG_LEVEL"
 
 	SPECTRAL_BIN_PATH = "SPECTRAL_BIN_PATH"
+	VACUUM_BIN_PATH   = "VACUUM_BIN_PATH"
+
+	GRAPHQL_LINTER_BIN_PATH = "GRAPHQL_LINTER_BIN_PATH"
+
+	DOC_TASK_WORKERS   = "DOC_TASK_WORKERS"
+	LINTER_CONCURRENCY = "LINTER_CONCURRENCY"
+
+	DRAFT_REPORT_TTL_MINUTES = "DRAFT_REPORT_TTL_MINUTES"
+
+	RELINT_VERSIONS_PER_PACKAGE = "RELINT_VERSIONS_PER_PACKAGE"
+	RELINT_MAX_ACTIVE_TASKS     = "RELINT_MAX_ACTIVE_TASKS"
+	LINT_IGNORE_EXTENSION       = "LINT_IGNORE_EXTENSION"
 
 	OLRIC_DISCOVERY_MODE = "OLRIC_DISCOVERY_MODE"
 	OLRIC_REPLICA_COUNT  = "OLRIC_REPLICA_COUNT"
@@ -74,6 +87,16 @@ type SystemInfoService interface {
 	GetLogLevel() string
 
 	GetSpectralBinPath() string
+	GetVacuumBinPath() string
+	GetGraphqlLinterBinPath() string
+	GetDocTaskWorkers() int
+	GetLinterConcurrency() int
+	GetDraftReportTtl() time.Duration
+	GetRelintVersionsPerPackage() int
+	GetRelintMaxActiveTasks() int
+	// GetLintIgnoreExtension returns the inline suppression extension. Lint results are cached regardless of it,
+	// so a changed extension applies only to the documents which are not linted yet with the same ruleset and linter version.
+	GetLintIgnoreExtension() string
 
 	GetOlricDiscoveryMode() string
 	GetReplicaCount() int
@@ -117,6 +140,26 @@ func (s systemInfoServiceImpl) Init() error {
 	if err := s.setSpectralBinPath(); err != nil {
 		return err
 	}
+	s.setVacuumBinPath()
+	s.setGraphqlLinterBinPath()
+	if err := s.setDocTaskWorkers(); err != nil {
+		return err
+	}
+	if err := s.setLinterConcurrency(); err != nil {
+		return err
+	}
+	if err := s.setDraftReportTtl(); err != nil {
+		return err
+	}
+	if err := s.setRelintVersionsPerPackage(); err != nil {
+		return err
+	}
+	if err := s.setRelintMaxActiveTasks(); err != nil {
+		return err
+	}
+	if err := s.setLintIgnoreExtension(); err != nil {
+		return err
+	}
 
 	s.setOlricDiscoveryMode()
 	s.setReplicaCount()
@@ -281,6 +324,130 @@ func (s systemInfoServiceImpl) GetSpectralBinPath() string {
 	return s.systemInfoMap[SPECTRAL_BIN_PATH].(string)
 }
 
+func (s systemInfoServiceImpl) setVacuumBinPath() {
+	// optional, vacuum linter is disabled if not set
+	s.systemInfoMap[VACUUM_BIN_PATH] = os.Getenv(VACUUM_BIN_PATH)
+}
+
+func (s systemInfoServiceImpl) GetVacuumBinPath() string {
+	return s.systemInfoMap[VACUUM_BIN_PATH].(string)
+}
+
+func (s systemInfoServiceImpl) setGraphqlLinterBinPath() {
+	// optional, graphql linter is disabled if not set
+	s.systemInfoMap[GRAPHQL_LINTER_BIN_PATH] = os.Getenv(GRAPHQL_LINTER_BIN_PATH)
+}
+
+func (s systemInfoServiceImpl) GetGraphqlLinterBinPath() string {
+	return s.systemInfoMap[GRAPHQL_LINTER_BIN_PATH].(string)
+}
+
+func (s systemInfoServiceImpl) setDocTaskWorkers() error {
+	workersStr := os.Getenv(DOC_TASK_WORKERS)
+	workers := 1
+	if workersStr != "" {
+		var err error
+		workers, err = strconv.Atoi(workersStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v env value: %v", DOC_TASK_WORKERS, err.Error())
+		}
+		if workers < 1 {
+			return fmt.Errorf("%v env value should be positive, got %d", DOC_TASK_WORKERS, workers)
+		}
+	}
+	s.systemInfoMap[DOC_TASK_WORKERS] = workers
+	return nil
+}
+
+func (s systemInfoServiceImpl) GetDocTaskWorkers() int {
+	return s.systemInfoMap[DOC_TASK_WORKERS].(int)
+}
+
+func (s systemInfoServiceImpl) setLinterConcurrency() error {
+	concurrencyStr := os.Getenv(LINTER_CONCURRENCY)
+	// by default every doc task worker is able to run the linter
+	concurrency := s.GetDocTaskWorkers()
+	if concurrencyStr != "" {
+		var err error
+		concurrency, err = strconv.Atoi(concurrencyStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v env value: %v", LINTER_CONCURRENCY, err.Error())
+		}
+		if concurrency < 1 {
+			return fmt.Errorf("%v env value should be positive, got %d", LINTER_CONCURRENCY, concurrency)
+		}
+	}
+	s.systemInfoMap[LINTER_CONCURRENCY] = concurrency
+	return nil
+}
+
+func (s systemInfoServiceImpl) GetLinterConcurrency() int {
+	return s.systemInfoMap[LINTER_CONCURRENCY].(int)
+}
+
+func (s systemInfoServiceImpl) setDraftReportTtl() error {
+	ttlStr := os.Getenv(DRAFT_REPORT_TTL_MINUTES)
+	ttlMinutes := 60
+	if ttlStr != "" {
+		var err error
+		ttlMinutes, err = strconv.Atoi(ttlStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v env value: %v", DRAFT_REPORT_TTL_MINUTES, err.Error())
+		}
+		if ttlMinutes < 1 {
+			return fmt.Errorf("%v env value should be positive, got %d", DRAFT_REPORT_TTL_MINUTES, ttlMinutes)
+		}
+	}
+	s.systemInfoMap[DRAFT_REPORT_TTL_MINUTES] = time.Duration(ttlMinutes) * time.Minute
+	return nil
+}
+
+func (s systemInfoServiceImpl) GetDraftReportTtl() time.Duration {
+	return s.systemInfoMap[DRAFT_REPORT_TTL_MINUTES].(time.Duration)
+}
+
+func (s systemInfoServiceImpl) setRelintVersionsPerPackage() error {
+	versionsStr := os.Getenv(RELINT_VERSIONS_PER_PACKAGE)
+	versions := 3
+	if versionsStr != "" {
+		var err error
+		versions, err = strconv.Atoi(versionsStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v env value: %v", RELINT_VERSIONS_PER_PACKAGE, err.Error())
+		}
+		if versions < 1 {
+			return fmt.Errorf("%v env value should be positive, got %d", RELINT_VERSIONS_PER_PACKAGE, versions)
+		}
+	}
+	s.systemInfoMap[RELINT_VERSIONS_PER_PACKAGE] = versions
+	return nil
+}
+
+func (s systemInfoServiceImpl) GetRelintVersionsPerPackage() int {
+	return s.systemInfoMap[RELINT_VERSIONS_PER_PACKAGE].(int)
+}
+
+func (s systemInfoServiceImpl) setRelintMaxActiveTasks() error {
+	tasksStr := os.Getenv(RELINT_MAX_ACTIVE_TASKS)
+	tasks := 5
+	if tasksStr != "" {
+		var err error
+		tasks, err = strconv.Atoi(tasksStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v env value: %v", RELINT_MAX_ACTIVE_TASKS, err.Error())
+		}
+		if tasks < 1 {
+			return fmt.Errorf("%v env value should be positive, got %d", RELINT_MAX_ACTIVE_TASKS, tasks)
+		}
+	}
+	s.systemInfoMap[RELINT_MAX_ACTIVE_TASKS] = tasks
+	return nil
+}
+
+func (s systemInfoServiceImpl) GetRelintMaxActiveTasks() int {
+	return s.systemInfoMap[RELINT_MAX_ACTIVE_TASKS].(int)
+}
+
 func (s systemInfoServiceImpl) setOlricDiscoveryMode() {
 	s.systemInfoMap[OLRIC_DISCOVERY_MODE] = os.Getenv(OLRIC_DISCOVERY_MODE)
 }
@@ -337,3 +504,19 @@ func (s systemInfoServiceImpl) IsProductionMode() bool {
 	}
 	return true
 }
+
+func (s systemInfoServiceImpl) setLintIgnoreExtension() error {
+	extension := os.Getenv(LINT_IGNORE_EXTENSION)
+	if extension == "" {
+		extension = "x-lint-ignore"
+	}
+	if !strings.HasPrefix(extension, "x-") {
+		return fmt.Errorf("%v env value should be a vendor extension name starting with 'x-', got %s", LINT_IGNORE_EXTENSION, extension)
+	}
+	s.systemInfoMap[LINT_IGNORE_EXTENSION] = extension
+	return nil
+}
+
+func (s systemInfoServiceImpl) GetLintIgnoreExtension() string {
+	return s.systemInfoMap[LINT_IGNORE_EXTENSION].(string)
+}
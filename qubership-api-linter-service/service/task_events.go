@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Netcracker/qubership-api-linter-service/db"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// TaskEventsService listens to Postgres notifications about task state changes and wakes up the subscribed processors.
+// Polling in the processors is kept as a safety net in case a notification is lost.
+type TaskEventsService interface {
+	Start()
+	// Subscribe returns the channel which receives a signal when the notification arrives on the pg channel.
+	// Signals are coalesced: several notifications received while the subscriber is busy result in one wake up.
+	Subscribe(pgChannel string) <-chan struct{}
+}
+
+func NewTaskEventsService(cp db.ConnectionProvider) TaskEventsService {
+	return &taskEventsServiceImpl{
+		cp:          cp,
+		subscribers: make(map[string][]chan struct{}),
+	}
+}
+
+type taskEventsServiceImpl struct {
+	cp          db.ConnectionProvider
+	mutex       sync.RWMutex
+	subscribers map[string][]chan struct{}
+}
+
+func (t *taskEventsServiceImpl) Subscribe(pgChannel string) <-chan struct{} {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	wakeC := make(chan struct{}, 1)
+	t.subscribers[pgChannel] = append(t.subscribers[pgChannel], wakeC)
+	return wakeC
+}
+
+func (t *taskEventsServiceImpl) Start() {
+	// go-pg listener reconnects automatically, so the channel is served until the listener is closed
+	ln := t.cp.GetConnection().Listen(context.Background(),
+		repository.VersionTaskCreatedChannel,
+		repository.DocTasksCreatedChannel,
+		repository.DocTaskFinishedChannel)
+
+	utils.SafeAsync(func() {
+		defer ln.Close()
+		for n := range ln.Channel() {
+			log.Tracef("Got task notification on channel %s: %s", n.Channel, n.Payload)
+			t.wakeUp(n.Channel)
+		}
+		log.Warnf("Task notification listener is closed, falling back to polling")
+	})
+}
+
+func (t *taskEventsServiceImpl) wakeUp(pgChannel string) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, wakeC := range t.subscribers[pgChannel] {
+		select {
+		case wakeC <- struct{}{}:
+		default:
+			// subscriber is already signalled
+		}
+	}
+}
@@ -16,98 +16,283 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
-	"path"
-	"runtime"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/Netcracker/qubership-api-linter-service/utils"
 	"github.com/Netcracker/qubership-api-linter-service/view"
-	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 )
 
-func (v validationServiceImpl) runDocumentsVacuum(documents []string) (*[]view.DocumentValidationEntity, error) {
-	err := os.MkdirAll(tempFolder, 0777)
+func NewVacuumExecutor(vacuumBinPath string, concurrency int) (LinterExecutor, error) {
+	vacuumVersion, err := detectVacuumVersion(vacuumBinPath)
 	if err != nil {
-		log.Errorf("failed to create temp folder: %v", err.Error())
+		return nil, err
 	}
-	resultReports := make([]view.DocumentValidationEntity, len(documents))
-	for i, doc := range documents {
-		fd, err := os.ReadFile(path.Join(os.TempDir(), doc))
-		if err != nil {
-			return nil, err
-		}
-		vacuumReport, errStr, calculationTime := vacuumExec(&view.ValidationData{Data: fd}, false)
-		documentValidation := view.DocumentValidationEntity{
-			Filename:        doc,
-			CalculationTime: calculationTime,
-		}
-		if errStr != "" {
-			log.Errorf(errStr)
-			documentValidation.Details = errStr
-		} else {
-			documentValidation.Summary = vacuumReport.Statistics
-			documentValidation.Report = vacuumReport.ResultSet.Results
-		}
-		resultReports[i] = documentValidation
-		log.Infof("vacuum validation of file '%s' took %d ms", documentValidation.Filename, documentValidation.CalculationTime)
-	}
-	return &resultReports, nil
+	return &vacuumExecutorImpl{vacuumBinPath: vacuumBinPath, semaphore: utils.NewSemaphore(concurrency), vacuumVersion: vacuumVersion}, nil
 }
 
-func vacuumExec(validationData *view.ValidationData, singleOperation bool) (*view.VacuumReport, string, int64) {
-	id := uuid.NewString()
-	tempDocumentPath := fmt.Sprintf("%v/%v", tempFolder, id)
-	document, err := os.Create(tempDocumentPath)
-	if err != nil {
-		log.Errorf("failed to create temp document file: %v", err.Error())
-		return nil, err.Error(), 0
-	}
-	defer os.Remove(tempDocumentPath)
-	_, err = document.Write(validationData.Data)
-	if err != nil {
-		log.Errorf("failed to write temp document file: %v", err.Error())
-		return nil, err.Error(), 0
+type vacuumExecutorImpl struct {
+	vacuumBinPath string
+	semaphore     *utils.Semaphore
+	vacuumVersion string
+}
+
+func (v *vacuumExecutorImpl) GetLinter() view.Linter {
+	return view.VacuumLinter
+}
+
+func (v *vacuumExecutorImpl) GetLinterVersion() string {
+	return v.vacuumVersion
+}
+
+func (v *vacuumExecutorImpl) SupportsApiType(apiType view.ApiType) bool {
+	switch apiType {
+	case view.OpenAPI31Type, view.OpenAPI30Type, view.OpenAPI20Type:
+		return true
+	default:
+		return false
 	}
-	document.Close()
-	executablePath := "resources/vacuum/windows/vacuum.exe"
-	args := []string{}
+}
+
+func (v *vacuumExecutorImpl) LintLocalDoc(docPath string, rulesetPath string) ([]byte, int64, error) {
+	v.semaphore.Acquire()
+	defer v.semaphore.Release()
+
+	var args []string
 	args = append(args, "report")
 	args = append(args, "-n") //minified json (m2m)
 	args = append(args, "-q") //disable output styling
 	args = append(args, "-o") //write to stdout instead of file
-	args = append(args, "-i") //read from stdin instead of file
-	if singleOperation {
-		args = append(args, "-k") //skip checking for a valid OpenAPI document
-	}
 	args = append(args, "-r") //use custom ruleset
-	args = append(args, "resources/vacuum/rules/rules.yaml")
-	if runtime.GOOS != "windows" {
-		executablePath = "resources/vacuum/linux/vacuum"
-	}
-	cmd := exec.Command(executablePath, args...)
-	inBuffer := bytes.Buffer{}
-	inBuffer.Write(validationData.Data)
-	cmd.Stdin = &inBuffer
+	args = append(args, rulesetPath)
+	functionsDir := filepath.Join(filepath.Dir(rulesetPath), rulesetFunctionsDir)
+	if _, err := os.Stat(functionsDir); err == nil {
+		args = append(args, "-f") //custom functions of the ruleset
+		args = append(args, functionsDir)
+	}
+	args = append(args, docPath)
+
+	limit := time.Minute * 10
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(limit))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, v.vacuumBinPath, args...)
 	var out bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
 
 	start := time.Now()
-	err = cmd.Run()
+	err := cmd.Run()
 	calculationTime := time.Since(start)
+
+	log.Tracef("stderr: %s", stderr.String())
+
 	if err != nil {
-		return nil, fmt.Sprintf("failed to get vacuum report: %v: %v", err.Error(), stderr.String()), calculationTime.Milliseconds()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errStr := fmt.Sprintf("lint time exceeded limit(%v)", limit)
+			if stderr.String() != "" {
+				errStr += " | stderr: " + stderr.String()
+			}
+			return nil, calculationTime.Milliseconds(), errors.New(errStr)
+		}
+		// vacuum exits with non-zero status if validation contains errors, the report is still written to stdout
+		if out.Len() == 0 {
+			return nil, calculationTime.Milliseconds(), makeLinterRunError("vacuum", err, stderr.String())
+		}
 	}
+	log.Tracef("vacuum report size is %d bytes", out.Len())
 
+	return out.Bytes(), calculationTime.Milliseconds(), nil
+}
+
+func (v *vacuumExecutorImpl) GetIssues(report []byte) ([]view.ValidationIssue, error) {
 	var vacuumReport view.VacuumReport
-	err = json.Unmarshal(out.Bytes(), &vacuumReport)
+	err := json.Unmarshal(report, &vacuumReport)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]view.ValidationIssue, 0)
+	for _, item := range vacuumReport.ResultSet.Results {
+		// vacuum takes the range from yaml nodes, so it is one-based unlike the common model
+		issueRange := view.IssueRange{
+			Start: view.IssuePosition{Line: max(item.Range.Start.Line-1, 0), Character: max(item.Range.Start.Character-1, 0)},
+			End:   view.IssuePosition{Line: max(item.Range.End.Line-1, 0), Character: max(item.Range.End.Character-1, 0)},
+		}
+		issues = append(issues, view.ValidationIssue{
+			Path:     splitJsonPath(item.Path),
+			Code:     item.RuleId,
+			Severity: view.ConvertVacuumSeverityToString(item.RuleSeverity),
+			Message:  item.Message,
+			Range:    &issueRange,
+		})
+	}
+	return issues, nil
+}
+
+func (v *vacuumExecutorImpl) FilterReport(report []byte, keep func(issue view.ValidationIssue) bool) ([]byte, error) {
+	// raw messages are used to keep the fields which are not mapped to the view
+	var reportObj map[string]json.RawMessage
+	err := json.Unmarshal(report, &reportObj)
+	if err != nil {
+		return nil, err
+	}
+	var resultSet map[string]json.RawMessage
+	err = json.Unmarshal(reportObj["resultSet"], &resultSet)
 	if err != nil {
-		return nil, fmt.Sprintf("failed to unmarshal vacuum report: %v", err.Error()), calculationTime.Milliseconds()
+		return nil, err
+	}
+	var items []json.RawMessage
+	err = json.Unmarshal(resultSet["results"], &items)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := v.GetIssues(report)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]json.RawMessage, 0, len(items))
+	summary := view.VacuumResultSummary{}
+	for i, item := range items {
+		if !keep(issues[i]) {
+			continue
+		}
+		kept = append(kept, item)
+		switch issues[i].Severity {
+		case "error":
+			summary.ErrorCount += 1
+		case "warning":
+			summary.WarningCount += 1
+		case "info":
+			summary.InfoCount += 1
+		case "hint":
+			summary.HintCount += 1
+		}
+	}
+	counts := map[string]int{
+		"errorCount":   summary.ErrorCount,
+		"warningCount": summary.WarningCount,
+		"infoCount":    summary.InfoCount,
+		"hintCount":    summary.HintCount,
+	}
+	for name, count := range counts {
+		resultSet[name], err = json.Marshal(count)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resultSet["results"], err = json.Marshal(kept)
+	if err != nil {
+		return nil, err
+	}
+	reportObj["resultSet"], err = json.Marshal(resultSet)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(reportObj)
+}
+
+func (v *vacuumExecutorImpl) CalculateSummary(report []byte) (map[string]interface{}, error) {
+	var vacuumReport view.VacuumReport
+	err := json.Unmarshal(report, &vacuumReport)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling result: %s", err)
+	}
+
+	// vacuum does not count hints in the result set, so calculating the summary explicitly
+	summary := view.VacuumResultSummary{}
+	for _, item := range vacuumReport.ResultSet.Results {
+		switch view.ConvertVacuumSeverityToString(item.RuleSeverity) {
+		case "error":
+			summary.ErrorCount += 1
+		case "warning":
+			summary.WarningCount += 1
+		case "info":
+			summary.InfoCount += 1
+		case "hint":
+			summary.HintCount += 1
+		}
+	}
+
+	sumJson, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling summary: %s", err)
+	}
+	var sumAsMap map[string]interface{}
+	err = json.Unmarshal(sumJson, &sumAsMap)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling summary: %s", err)
+	}
+	return sumAsMap, nil
+}
+
+func (v *vacuumExecutorImpl) ParseSummary(summary map[string]interface{}) (*view.IssuesSummary, error) {
+	return makeIssuesSummary(summary)
+}
+
+func detectVacuumVersion(vacuumBinPath string) (string, error) {
+	if vacuumBinPath == "" {
+		return "", fmt.Errorf("vacuum executor path is not set (VACUUM_BIN_PATH env)")
+	}
+	cmd := exec.Command(vacuumBinPath, "version")
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("error getting vacuum version: %s", err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// splitJsonPath converts vacuum JSON path (e.g. $.paths['/pets'].get.responses['200']) to path segments
+func splitJsonPath(jsonPath string) []string {
+	result := make([]string, 0)
+	path := strings.TrimPrefix(jsonPath, "$")
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, "['"):
+			end := strings.Index(path, "']")
+			if end < 0 {
+				return append(result, path[2:])
+			}
+			result = append(result, path[2:end])
+			path = path[end+2:]
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return append(result, path[1:])
+			}
+			result = append(result, path[1:end])
+			path = path[end+1:]
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			if end > 0 {
+				result = append(result, path[:end])
+			}
+			path = path[end:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			result = append(result, path[:end])
+			path = path[end:]
+		}
 	}
-	return &vacuumReport, "", calculationTime.Milliseconds()
+	return result
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitJsonPath(t *testing.T) {
+	tests := []struct {
+		jsonPath string
+		expected []string
+	}{
+		{"", []string{}},
+		{"$", []string{}},
+		{"$.info.title", []string{"info", "title"}},
+		{"$.paths['/items/{id}'].get", []string{"paths", "/items/{id}", "get"}},
+		{"$.paths['/items'].get.responses['200']", []string{"paths", "/items", "get", "responses", "200"}},
+		{"$.tags[0].name", []string{"tags", "0", "name"}},
+		{"$.servers[1][0]", []string{"servers", "1", "0"}},
+		{"$['info'].version", []string{"info", "version"}},
+		{"info.title", []string{"info", "title"}},
+		{"$.paths['/a.b'].get", []string{"paths", "/a.b", "get"}},
+		{"$.paths['/unterminated", []string{"paths", "/unterminated"}},
+		{"$.tags[0", []string{"tags", "0"}},
+	}
+	for _, test := range tests {
+		t.Run(test.jsonPath, func(t *testing.T) {
+			actual := splitJsonPath(test.jsonPath)
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("splitJsonPath(%q) = %q, expected %q", test.jsonPath, actual, test.expected)
+			}
+		})
+	}
+}
@@ -16,7 +16,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/Netcracker/qubership-api-linter-service/client"
 	"github.com/Netcracker/qubership-api-linter-service/entity"
@@ -31,7 +30,7 @@ import (
 )
 
 type ValidationService interface {
-	ValidateVersion(ctx context.Context, packageId string, version string, eventId string) (string, error)
+	ValidateVersion(ctx context.Context, packageId string, version string, eventId string, priority int) (string, error)
 	GetVersionSummary(ctx context.Context, packageId string, version string) (*view.ValidationSummaryForVersion, error)
 	GetValidationResult(ctx context.Context, packageId string, version string, slug string) (*view.DocumentResult, error)
 }
@@ -43,6 +42,9 @@ func NewValidationService(
 	rulesetRepository repository.RulesetRepository,
 	docLintTaskRepository repository.DocLintTaskRepository,
 	versionTaskProcessor VersionTaskProcessor,
+	linterRegistry LinterRegistry,
+	ruleCatalogService RuleCatalogService,
+	waiverService WaiverService,
 	apihubClient client.ApihubClient,
 	executorId string) ValidationService {
 	return &validationServiceImpl{
@@ -52,6 +54,9 @@ func NewValidationService(
 		rulesetRepository:       rulesetRepository,
 		docLintTaskRepository:   docLintTaskRepository,
 		versionTaskProcessor:    versionTaskProcessor,
+		linterRegistry:          linterRegistry,
+		ruleCatalogService:      ruleCatalogService,
+		waiverService:           waiverService,
 		apihubClient:            apihubClient,
 		executorId:              executorId,
 	}
@@ -65,6 +70,9 @@ type validationServiceImpl struct {
 	docLintTaskRepository   repository.DocLintTaskRepository
 
 	versionTaskProcessor VersionTaskProcessor
+	linterRegistry       LinterRegistry
+	ruleCatalogService   RuleCatalogService
+	waiverService        WaiverService
 	apihubClient         client.ApihubClient
 	executorId           string
 }
@@ -102,11 +110,21 @@ func (v validationServiceImpl) GetVersionSummary(ctx context.Context, packageId
 		Documents: nil,
 		Rulesets:  nil,
 	}
+	if lintedVer.QualityGateStatus != "" {
+		result.QualityGate = &view.QualityGateVerdict{
+			Status:  lintedVer.QualityGateStatus,
+			Reasons: lintedVer.QualityGateReasons,
+		}
+	}
 
 	rulesetMap, err := v.makeRulesetMap(ctx, makeRulesetIdsFromLintedDocs(lintedDocs))
 	if err != nil {
 		return nil, err
 	}
+	waivers, err := v.waiverService.GetVersionWaivers(ctx, packageId, ver)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, doc := range lintedDocs {
 		if doc.LintStatus == view.StatusError {
@@ -133,38 +151,53 @@ func (v validationServiceImpl) GetVersionSummary(ctx context.Context, packageId
 			return nil, fmt.Errorf("ruleset with id %s is not found in cache map", doc.RulesetId)
 		}
 
-		var summ *view.IssuesSummary
-
-		switch ruleset.Linter {
-		case view.SpectralLinter:
-			// calculate spectral summary
-			summ, err = makeSpectralSummary(resultSummary.Summary)
-			if err != nil {
-				return nil, err
-			}
-			if summ == nil {
-				return nil, fmt.Errorf("failed to calculate spectral result summary")
-			}
-		case view.UnknownLinter:
-			return nil, fmt.Errorf("unknown linter %s", ruleset.Linter)
-		default:
-			return nil, fmt.Errorf("unknown linter %s", ruleset.Linter)
+		linter, err := v.linterRegistry.GetLinter(ruleset.Linter)
+		if err != nil {
+			return nil, err
 		}
-
-		result.Documents = append(result.Documents, view.ValidationDocument{
+		validationDoc := view.ValidationDocument{
 			Status:       doc.LintStatus,
 			Details:      doc.LintDetails,
 			Slug:         doc.Slug,
 			ApiType:      doc.SpecificationType,
 			DocumentName: doc.FileId,
 			RulesetId:    doc.RulesetId,
-			IssuesSummary: &view.IssuesSummary{
-				Error:   summ.Error,
-				Warning: summ.Warning,
-				Info:    summ.Info,
-				Hint:    summ.Hint,
-			},
-		})
+		}
+		validationDoc.SuppressedIssues = getSuppressedIssues(resultSummary.Summary)
+		if waivers.HasDocumentWaivers(doc.Slug) {
+			// the stored summary includes waived issues, so the issues are counted again
+			lintResult, err := v.lintResultRepository.GetLintResult(ctx, doc.DataHash, doc.RulesetId)
+			if err != nil {
+				return nil, err
+			}
+			if lintResult == nil {
+				continue
+			}
+			issues, err := linter.GetIssues(lintResult.Data)
+			if err != nil {
+				return nil, err
+			}
+			summ, waivedSumm := waivers.Summarize(doc.Slug, issues)
+			validationDoc.IssuesSummary = &summ
+			validationDoc.WaivedIssuesSummary = &waivedSumm
+			result.Documents = append(result.Documents, validationDoc)
+			continue
+		}
+
+		summ, err := linter.ParseSummary(resultSummary.Summary)
+		if err != nil {
+			return nil, err
+		}
+		if summ == nil {
+			return nil, fmt.Errorf("failed to calculate %s result summary", ruleset.Linter)
+		}
+		validationDoc.IssuesSummary = &view.IssuesSummary{
+			Error:   summ.Error,
+			Warning: summ.Warning,
+			Info:    summ.Info,
+			Hint:    summ.Hint,
+		}
+		result.Documents = append(result.Documents, validationDoc)
 	}
 
 	for _, val := range rulesetMap {
@@ -212,76 +245,44 @@ func (v validationServiceImpl) GetValidationResult(ctx context.Context, packageI
 		return nil, nil
 	}
 
-	issues := make([]view.ValidationIssue, 0)
-	var spectralOutput []view.SpectralOutputItem
-	err = json.Unmarshal(lintResult.Data, &spectralOutput)
+	linter, err := v.linterRegistry.GetLinter(ruleset.Linter)
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range spectralOutput {
-		var path []string
-		if item.Path != nil {
-			path = item.Path
-		} else {
-			path = make([]string, 0)
-		}
-		issues = append(issues, view.ValidationIssue{
-			Path:     path,
-			Code:     item.Code,
-			Severity: view.ConvertSpectralSeverityToString(item.Severity),
-			Message:  item.Message,
-		})
+	issues, err := linter.GetIssues(lintResult.Data)
+	if err != nil {
+		return nil, err
 	}
+	v.ruleCatalogService.AddRuleDescriptions(ctx, ruleset.Id, issues)
+	waivers, err := v.waiverService.GetVersionWaivers(ctx, packageId, ver)
+	if err != nil {
+		return nil, err
+	}
+	issues, waivedIssues := waivers.Apply(lintedDocument.Slug, issues)
 
 	result := view.DocumentResult{
 		Ruleset:           entity.MakeRulesetView(*ruleset),
 		Issues:            issues,
+		WaivedIssues:      waivedIssues,
 		ValidatedDocument: entity.MakeValidatedDocumentView(*lintedDocument),
 	}
 
 	return &result, nil
 }
 
-func makeSpectralSummary(summary map[string]interface{}) (*view.IssuesSummary, error) {
-	result := view.IssuesSummary{}
-	errCStr, ok := summary["errorCount"] // summary could be empty
-	if ok {
-		if errC, ok := errCStr.(float64); ok {
-			result.Error = int(errC)
-		}
-	}
-	warningCStr, ok := summary["warningCount"]
-	if ok {
-		if warningC, ok := warningCStr.(float64); ok {
-			result.Warning = int(warningC)
-		}
-	}
-
-	infoCStr, ok := summary["infoCount"]
-	if ok {
-		if infoC, ok := infoCStr.(float64); ok {
-			result.Info = int(infoC)
-		}
-	}
-
-	hintCStr, ok := summary["hintCount"]
-	if ok {
-		if infoC, ok := hintCStr.(float64); ok {
-			result.Hint = int(infoC)
-		}
-	}
-
-	return &result, nil
+func (v validationServiceImpl) getVersionAndRevision(ctx context.Context, packageId string, version string) (string, int, error) {
+	return resolveVersionRevision(ctx, v.apihubClient, packageId, version)
 }
 
-func (v validationServiceImpl) getVersionAndRevision(ctx context.Context, packageId string, version string) (string, int, error) {
+// resolveVersionRevision splits the version into name and revision, the latest revision is requested from apihub if it is not specified
+func resolveVersionRevision(ctx context.Context, apihubClient client.ApihubClient, packageId string, version string) (string, int, error) {
 	ver, rev, err := utils.SplitVersionRevision(version)
 	if err != nil {
 		return "", 0, err
 	}
 
 	if rev == 0 {
-		versionView, err := v.apihubClient.GetVersion(ctx, packageId, version)
+		versionView, err := apihubClient.GetVersion(ctx, packageId, version)
 		if err != nil {
 			return "", 0, err
 		}
@@ -296,7 +297,7 @@ func (v validationServiceImpl) getVersionAndRevision(ctx context.Context, packag
 	return ver, rev, nil
 }
 
-func (v validationServiceImpl) ValidateVersion(ctx context.Context, packageId string, version string, eventId string) (string, error) {
+func (v validationServiceImpl) ValidateVersion(ctx context.Context, packageId string, version string, eventId string, priority int) (string, error) {
 	pkg, err := v.apihubClient.GetPackageById(ctx, packageId)
 	if err != nil {
 		return "", err
@@ -332,7 +333,7 @@ func (v validationServiceImpl) ValidateVersion(ctx context.Context, packageId st
 		LastActive:   time.Now(),
 		EventId:      eventId, // optional
 		RestartCount: 0,
-		Priority:     0,
+		Priority:     priority,
 	}
 	err = v.verTaskRepo.SaveVersionTask(context.Background(), ent)
 	if err != nil {
@@ -342,8 +343,6 @@ func (v validationServiceImpl) ValidateVersion(ctx context.Context, packageId st
 	return ent.Id, nil
 }
 
-const tempFolder = "tmp"
-
 func (v validationServiceImpl) makeRulesetMap(ctx context.Context, rulesetIds []string) (map[string]entity.Ruleset, error) {
 	rulesetMap := make(map[string]entity.Ruleset)
 	for _, rulesetId := range rulesetIds {
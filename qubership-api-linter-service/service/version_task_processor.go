@@ -12,7 +12,6 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"os"
-	"sync/atomic"
 	"time"
 )
 
@@ -20,22 +19,26 @@ type VersionTaskProcessor interface {
 	StartVersionLintTask(taskId string) error
 }
 
-func NewVersionTaskProcessor(verRepo repository.VersionLintTaskRepository, docRepo repository.DocLintTaskRepository, verResRepo repository.VersionResultRepository, cl client.ApihubClient, linterSelectorService LinterSelectorService, executorId string) VersionTaskProcessor {
+func NewVersionTaskProcessor(verRepo repository.VersionLintTaskRepository, docRepo repository.DocLintTaskRepository, verResRepo repository.VersionResultRepository, cl client.ApihubClient, linterSelectorService LinterSelectorService, taskEventsService TaskEventsService, lintDiffService LintDiffService, qualityGateService QualityGateService, executorId string) VersionTaskProcessor {
 	svc := &versionTaskProcessorImpl{
 		verRepo:               verRepo,
 		docRepo:               docRepo,
 		verResRepo:            verResRepo,
 		cl:                    cl,
 		linterSelectorService: linterSelectorService,
+		lintDiffService:       lintDiffService,
+		qualityGateService:    qualityGateService,
 		executorId:            executorId,
 	}
 
+	taskCreatedC := taskEventsService.Subscribe(repository.VersionTaskCreatedChannel)
 	utils.SafeAsync(func() {
-		svc.acquireFreeTasks()
+		svc.acquireFreeTasks(taskCreatedC)
 	})
 
+	docFinishedC := taskEventsService.Subscribe(repository.DocTaskFinishedChannel)
 	utils.SafeAsync(func() {
-		svc.checkDocReady()
+		svc.checkDocReady(docFinishedC)
 	})
 
 	return svc
@@ -47,6 +50,8 @@ type versionTaskProcessorImpl struct {
 	verResRepo            repository.VersionResultRepository
 	cl                    client.ApihubClient
 	linterSelectorService LinterSelectorService
+	lintDiffService       LintDiffService
+	qualityGateService    QualityGateService
 	executorId            string
 }
 
@@ -97,7 +102,7 @@ func (v versionTaskProcessorImpl) processVersionLintTask(taskId string) {
 	for _, doc := range docs.Documents {
 		_, exists := typeToLinter[doc.Type]
 		if !exists {
-			linter, rulesetId, err := v.linterSelectorService.SelectLinterAndRuleset(ctx, doc.Type)
+			linter, rulesetId, err := v.linterSelectorService.SelectLinterAndRuleset(ctx, task.PackageId, doc.Type)
 
 			typeToLinter[doc.Type] = linterAndRuleset{
 				linter:    linter,
@@ -110,13 +115,13 @@ func (v versionTaskProcessorImpl) processVersionLintTask(taskId string) {
 	var docTasks []entity.DocumentLintTask
 
 	for _, doc := range docs.Documents {
-		if !supportedApiType(doc.Type) {
+		lr := typeToLinter[doc.Type]
+
+		if lr.linter == view.UnknownLinter && lr.err == nil {
 			log.Infof("Skipping document %s for [ %s | %s ] with unsupported api type: %s", doc.Slug, task.PackageId, task.Version, doc.Type)
 			continue
 		}
 
-		lr := typeToLinter[doc.Type]
-
 		status := view.TaskStatusNotStarted
 		details := ""
 		executorId := ""
@@ -150,8 +155,9 @@ func (v versionTaskProcessorImpl) processVersionLintTask(taskId string) {
 			ExecutorId:        executorId,
 			LastActive:        nil,
 			RestartCount:      0,
-			Priority:          0,
+			Priority:          task.Priority,
 			LintTimeMs:        0,
+			ApihubChecksum:    doc.Checksum,
 		}
 
 		docTasks = append(docTasks, docTaskEnt)
@@ -176,27 +182,12 @@ func (v versionTaskProcessorImpl) processVersionLintTask(taskId string) {
 	log.Infof("Version lint task for [ %s | %s ] (id = %s) is processed, %d doc lint task(s) created. Processing time = %dms", task.PackageId, task.Version, taskId, len(docTasks), time.Since(start).Milliseconds())
 }
 
-func supportedApiType(at view.ApiType) bool {
-	switch at {
-	case view.OpenAPI20Type, view.OpenAPI30Type, view.OpenAPI31Type:
-		return true
-	default:
-		return false
-	}
-}
-
-func (v versionTaskProcessorImpl) acquireFreeTasks() {
+// acquireFreeTasks processes free tasks when a new task is created, the ticker is a safety net for lost notifications and stale tasks
+func (v versionTaskProcessorImpl) acquireFreeTasks(taskCreatedC <-chan struct{}) {
 	t := time.NewTicker(time.Second * 5)
 
-	running := atomic.Bool{}
-	for range t.C {
-		if running.Load() {
-			log.Tracef("versionTaskProcessorImpl: ticker skipped, running")
-			continue
-		}
-
-		utils.SafeAsync(func() {
-			running.Store(true)
+	for {
+		utils.SafeSync(func() {
 			for {
 				moreWork := v.processTask()
 				if moreWork == false {
@@ -204,8 +195,13 @@ func (v versionTaskProcessorImpl) acquireFreeTasks() {
 				}
 				log.Tracef("versionTaskProcessorImpl: keep on running")
 			}
-			running.Store(false)
 		})
+
+		select {
+		case <-t.C:
+		case <-taskCreatedC:
+			log.Tracef("versionTaskProcessorImpl: woken up by new version task")
+		}
 	}
 }
 
@@ -224,10 +220,16 @@ func (v versionTaskProcessorImpl) processTask() bool {
 	return false
 }
 
-func (v versionTaskProcessorImpl) checkDocReady() {
+// checkDocReady completes version tasks when a doc task is finished, the ticker is a safety net for lost notifications
+func (v versionTaskProcessorImpl) checkDocReady(docFinishedC <-chan struct{}) {
 	t := time.NewTicker(time.Second * 5)
 	ctx := context.Background()
-	for range t.C {
+	for {
+		select {
+		case <-t.C:
+		case <-docFinishedC:
+		}
+
 		verLintTasks, err := v.verRepo.GetWaitingForDocTasks(ctx, v.executorId) // FIXME: problem with dead executor here!!
 		if err != nil {
 			log.Errorf("Failed to get version tasks in waiting for docs status: %s", err)
@@ -297,6 +299,7 @@ func (v versionTaskProcessorImpl) checkDocReady() {
 					lintedVerEnt.LintDetails = ""
 				}
 				lintedVerEnt.LintedAt = time.Now()
+				v.evaluateVersion(lintedVerEnt)
 
 				err = v.verRepo.VersionLintCompleted(ctx, verLintTask.Id, lintedVerEnt)
 				if err != nil {
@@ -310,9 +313,23 @@ func (v versionTaskProcessorImpl) checkDocReady() {
 
 }
 
+// evaluateVersion builds the lint diff with the previous version and sets the quality gate verdict, so the verdict is saved
+// together with the version status. Failed diff or quality gate evaluation doesn't fail the version lint.
+func (v versionTaskProcessorImpl) evaluateVersion(ver *entity.LintedVersion) {
+	ctx := secctx.MakeSysadminContext(context.Background())
+	diff, diffErr := v.lintDiffService.BuildVersionDiff(ctx, *ver)
+	if diffErr != nil {
+		log.Errorf("Failed to build lint diff for [ %s | %s@%d ]: %s", ver.PackageId, ver.Version, ver.Revision, diffErr)
+	}
+	err := v.qualityGateService.EvaluateVersion(ctx, ver, diff, diffErr)
+	if err != nil {
+		setQualityGateError(ver, err)
+	}
+}
+
 func (v versionTaskProcessorImpl) handleProcessingFailed(ctx context.Context, verLintTask entity.VersionLintTask, taskErr error) {
 	if verLintTask.RestartCount >= 2 {
-		log.Error("Failed to process version task %s with status = %s: %s. No more retries.", verLintTask.Id, verLintTask.Status, taskErr)
+		log.Errorf("Failed to process version task %s with status = %s: %s. No more retries.", verLintTask.Id, verLintTask.Status, taskErr)
 		updErr := v.verRepo.VersionLintFailed(ctx, verLintTask.Id, fmt.Sprintf("failed to save version lint finished status: %s", taskErr))
 		if updErr != nil {
 			log.Errorf("Failed to update version lint task %s status to %s: %v", verLintTask.Id, view.TaskStatusError, updErr)
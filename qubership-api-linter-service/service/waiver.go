@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-api-linter-service/client"
+	"github.com/Netcracker/qubership-api-linter-service/entity"
+	"github.com/Netcracker/qubership-api-linter-service/exception"
+	"github.com/Netcracker/qubership-api-linter-service/repository"
+	"github.com/Netcracker/qubership-api-linter-service/secctx"
+	"github.com/Netcracker/qubership-api-linter-service/utils"
+	"github.com/Netcracker/qubership-api-linter-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// WaiverService manages accepted violations of a package. Waivers are applied when lint results are read,
+// so expired and revoked waivers stop working without re-lint. The stored quality gate verdicts of the affected versions
+// are evaluated again when a waiver is created or revoked, expired waivers are reflected in the verdict on the next lint.
+type WaiverService interface {
+	CreateWaiver(ctx context.Context, packageId string, req view.CreateIssueWaiverRequest) (*view.IssueWaiver, error)
+	ListWaivers(ctx context.Context, packageId string, activeOnly bool) (*view.IssueWaiversResponse, error)
+	RevokeWaiver(ctx context.Context, packageId string, waiverId string) error
+	// GetVersionWaivers returns active waivers of the package which are applicable to the version
+	GetVersionWaivers(ctx context.Context, packageId string, version string) (VersionWaivers, error)
+}
+
+func NewWaiverService(waiverRepository repository.WaiverRepository, qualityGateService QualityGateService, apihubClient client.ApihubClient) WaiverService {
+	return &waiverServiceImpl{
+		waiverRepository:   waiverRepository,
+		qualityGateService: qualityGateService,
+		apihubClient:       apihubClient,
+	}
+}
+
+type waiverServiceImpl struct {
+	waiverRepository   repository.WaiverRepository
+	qualityGateService QualityGateService
+	apihubClient       client.ApihubClient
+}
+
+func (w waiverServiceImpl) CreateWaiver(ctx context.Context, packageId string, req view.CreateIssueWaiverRequest) (*view.IssueWaiver, error) {
+	err := validateWaiverRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := w.apihubClient.GetPackageById(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "package", "id": packageId},
+		}
+	}
+
+	now := time.Now()
+	ent := entity.IssueWaiver{
+		Id:            uuid.NewString(),
+		PackageId:     packageId,
+		VersionFrom:   req.VersionFrom,
+		VersionTo:     req.VersionTo,
+		Slug:          req.Slug,
+		RuleCode:      req.RuleCode,
+		Path:          req.Path,
+		Justification: req.Justification,
+		ExpiresAt:     req.ExpiresAt,
+		CreatedAt:     now,
+		CreatedBy:     secctx.GetUserId(ctx),
+	}
+	err = w.waiverRepository.SaveWaiver(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Issue waiver %s for package %s was created by %s, expires at %s", ent.Id, packageId, ent.CreatedBy, ent.ExpiresAt)
+	w.reevaluateQualityGate(ent)
+
+	result := entity.MakeIssueWaiverView(ent, now)
+	return &result, nil
+}
+
+func (w waiverServiceImpl) ListWaivers(ctx context.Context, packageId string, activeOnly bool) (*view.IssueWaiversResponse, error) {
+	ents, err := w.waiverRepository.GetWaivers(ctx, packageId, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result := &view.IssueWaiversResponse{
+		PackageId: packageId,
+		Waivers:   make([]view.IssueWaiver, 0, len(ents)),
+	}
+	for _, ent := range ents {
+		result.Waivers = append(result.Waivers, entity.MakeIssueWaiverView(ent, now))
+	}
+	return result, nil
+}
+
+func (w waiverServiceImpl) RevokeWaiver(ctx context.Context, packageId string, waiverId string) error {
+	revoked, err := w.waiverRepository.RevokeWaiver(ctx, packageId, waiverId, secctx.GetUserId(ctx))
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.EntityNotFound,
+			Message: exception.EntityNotFoundMsg,
+			Params:  map[string]interface{}{"entity": "issue waiver", "id": waiverId},
+		}
+	}
+	log.Infof("Issue waiver %s for package %s was revoked", waiverId, packageId)
+	ent, err := w.waiverRepository.GetWaiver(ctx, packageId, waiverId)
+	if err != nil {
+		log.Errorf("Failed to get revoked issue waiver %s: %s", waiverId, err)
+		return nil
+	}
+	if ent != nil {
+		w.reevaluateQualityGate(*ent)
+	}
+	return nil
+}
+
+func (w waiverServiceImpl) GetVersionWaivers(ctx context.Context, packageId string, version string) (VersionWaivers, error) {
+	return getVersionWaivers(ctx, w.waiverRepository, packageId, version)
+}
+
+// reevaluateQualityGate updates the quality gate verdicts of the versions in the waiver range in background
+func (w waiverServiceImpl) reevaluateQualityGate(waiver entity.IssueWaiver) {
+	utils.SafeAsync(func() {
+		ctx := secctx.MakeSysadminContext(context.Background())
+		w.qualityGateService.ReevaluateVersions(ctx, waiver.PackageId, func(version string) bool {
+			return isVersionInWaiverRange(waiver, version)
+		})
+	})
+}
+
+// getVersionWaivers returns active waivers of the package which are applicable to the version
+func getVersionWaivers(ctx context.Context, waiverRepository repository.WaiverRepository, packageId string, version string) (VersionWaivers, error) {
+	ents, err := waiverRepository.GetWaivers(ctx, packageId, true)
+	if err != nil {
+		return nil, err
+	}
+	var result VersionWaivers
+	for _, ent := range ents {
+		if isVersionInWaiverRange(ent, version) {
+			result = append(result, ent)
+		}
+	}
+	return result, nil
+}
+
+func isVersionInWaiverRange(waiver entity.IssueWaiver, version string) bool {
+	if waiver.VersionFrom != "" && utils.CompareVersionNames(version, waiver.VersionFrom) < 0 {
+		return false
+	}
+	if waiver.VersionTo != "" && utils.CompareVersionNames(version, waiver.VersionTo) > 0 {
+		return false
+	}
+	return true
+}
+
+// VersionWaivers are active waivers applicable to one version
+type VersionWaivers []entity.IssueWaiver
+
+// HasDocumentWaivers returns true if any waiver may match issues of the document
+func (v VersionWaivers) HasDocumentWaivers(slug string) bool {
+	for _, waiver := range v {
+		if waiver.Slug == "" || waiver.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply splits the document issues to not waived and waived ones, WaiverId is set for waived issues
+func (v VersionWaivers) Apply(slug string, issues []view.ValidationIssue) ([]view.ValidationIssue, []view.ValidationIssue) {
+	if !v.HasDocumentWaivers(slug) {
+		return issues, nil
+	}
+	notWaived := make([]view.ValidationIssue, 0, len(issues))
+	waived := make([]view.ValidationIssue, 0)
+	for _, issue := range issues {
+		waiverId := v.match(slug, issue)
+		if waiverId == "" {
+			notWaived = append(notWaived, issue)
+			continue
+		}
+		issue.WaiverId = waiverId
+		waived = append(waived, issue)
+	}
+	return notWaived, waived
+}
+
+// Summarize returns summaries of not waived and waived issues of the document
+func (v VersionWaivers) Summarize(slug string, issues []view.ValidationIssue) (view.IssuesSummary, view.IssuesSummary) {
+	var summary, waivedSummary view.IssuesSummary
+	notWaived, waived := v.Apply(slug, issues)
+	for _, issue := range notWaived {
+		summary.AddIssue(issue.Severity)
+	}
+	for _, issue := range waived {
+		waivedSummary.AddIssue(issue.Severity)
+	}
+	return summary, waivedSummary
+}
+
+// GetJustification returns the justification of the waiver, empty string if the waiver is not found
+func (v VersionWaivers) GetJustification(waiverId string) string {
+	for _, waiver := range v {
+		if waiver.Id == waiverId {
+			return waiver.Justification
+		}
+	}
+	return ""
+}
+
+func (v VersionWaivers) match(slug string, issue view.ValidationIssue) string {
+	for _, waiver := range v {
+		if waiver.Slug != "" && waiver.Slug != slug {
+			continue
+		}
+		if waiver.RuleCode != "" && waiver.RuleCode != issue.Code {
+			continue
+		}
+		if waiver.Path != "" && !matchWaiverPath(waiver.Path, issue.Path) {
+			continue
+		}
+		return waiver.Id
+	}
+	return ""
+}
+
+// matchWaiverPath checks if the issue is at the JSON pointer or below it, "*" segment matches any segment
+func matchWaiverPath(pointer string, path []string) bool {
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	if len(segments) > len(path) {
+		return false
+	}
+	for i, segment := range segments {
+		if segment == "*" {
+			continue
+		}
+		if utils.UnescapeJsonPointerSegment(segment) != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func validateWaiverRequest(req view.CreateIssueWaiverRequest) error {
+	if strings.TrimSpace(req.Justification) == "" || req.ExpiresAt.IsZero() {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "justification, expiresAt"},
+		}
+	}
+	if !req.ExpiresAt.After(time.Now()) {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "expiresAt", "value": req.ExpiresAt},
+		}
+	}
+	if req.Path != "" && !strings.HasPrefix(req.Path, "/") {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "path", "value": req.Path},
+		}
+	}
+	if req.VersionFrom != "" && req.VersionTo != "" && utils.CompareVersionNames(req.VersionFrom, req.VersionTo) > 0 {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "versionTo", "value": req.VersionTo},
+		}
+	}
+	return nil
+}
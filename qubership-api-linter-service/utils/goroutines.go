@@ -31,6 +31,11 @@ func SafeAsync(function noPanicFunc) {
 	go function.run()
 }
 
+// SafeSync runs the function in the current goroutine, panic is logged and does not break the caller
+func SafeSync(function noPanicFunc) {
+	function.run()
+}
+
 func internalRecover() {
 	if err := recover(); err != nil {
 		log.Errorf("Request failed with panic: %v", err)
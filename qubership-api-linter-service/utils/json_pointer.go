@@ -0,0 +1,25 @@
+package utils
+
+import "strings"
+
+// MakeJsonPointer makes RFC 6901 JSON pointer from the path segments
+func MakeJsonPointer(path []string) string {
+	var sb strings.Builder
+	for _, segment := range path {
+		sb.WriteString("/")
+		sb.WriteString(EscapeJsonPointerSegment(segment))
+	}
+	return sb.String()
+}
+
+// EscapeJsonPointerSegment escapes "~" and "/" of the path segment as "~0" and "~1"
+func EscapeJsonPointerSegment(segment string) string {
+	segment = strings.ReplaceAll(segment, "~", "~0")
+	return strings.ReplaceAll(segment, "/", "~1")
+}
+
+// UnescapeJsonPointerSegment is the reverse of EscapeJsonPointerSegment, "~1" is replaced first so "~01" becomes "~1"
+func UnescapeJsonPointerSegment(segment string) string {
+	segment = strings.ReplaceAll(segment, "~1", "/")
+	return strings.ReplaceAll(segment, "~0", "~")
+}
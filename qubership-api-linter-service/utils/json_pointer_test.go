@@ -0,0 +1,31 @@
+package utils
+
+import "testing"
+
+func TestMakeJsonPointer(t *testing.T) {
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"info", "title"}, "/info/title"},
+		{[]string{"paths", "/items/{id}", "get"}, "/paths/~1items~1{id}/get"},
+		{[]string{"components", "schemas", "a~b"}, "/components/schemas/a~0b"},
+		{[]string{"x-ext", "~1"}, "/x-ext/~01"},
+		{[]string{""}, "/"},
+	}
+	for _, test := range tests {
+		if actual := MakeJsonPointer(test.path); actual != test.expected {
+			t.Errorf("MakeJsonPointer(%q) = %q, expected %q", test.path, actual, test.expected)
+		}
+	}
+}
+
+func TestJsonPointerSegmentRoundTrip(t *testing.T) {
+	for _, segment := range []string{"", "plain", "/items/{id}", "a~b", "~1", "~0/", "~~//"} {
+		escaped := EscapeJsonPointerSegment(segment)
+		if actual := UnescapeJsonPointerSegment(escaped); actual != segment {
+			t.Errorf("segment %q escaped as %q is unescaped as %q", segment, escaped, actual)
+		}
+	}
+}
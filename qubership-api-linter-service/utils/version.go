@@ -42,3 +42,35 @@ func SplitVersionRevision(version string) (string, int, error) {
 	}
 	return versionName, versionRevision, nil
 }
+
+// CompareVersionNames compares version names in natural order, numeric parts are compared as numbers (2024.9 < 2024.10)
+func CompareVersionNames(a string, b string) int {
+	for a != "" && b != "" {
+		partA, restA := nextVersionPart(a)
+		partB, restB := nextVersionPart(b)
+		numA, errA := strconv.Atoi(partA)
+		numB, errB := strconv.Atoi(partB)
+		if errA == nil && errB == nil {
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		} else if c := strings.Compare(partA, partB); c != 0 {
+			return c
+		}
+		a, b = restA, restB
+	}
+	return strings.Compare(a, b)
+}
+
+// nextVersionPart splits the leading run of digits or non-digits
+func nextVersionPart(s string) (string, string) {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	i := 1
+	for i < len(s) && isDigit(s[i]) == isDigit(s[0]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
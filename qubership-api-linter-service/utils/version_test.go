@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestCompareVersionNames(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"2024.9", "2024.9", 0},
+		{"2024.9", "2024.10", -1},
+		{"2024.10", "2024.9", 1},
+		{"2023.4", "2024.1", -1},
+		{"1.0", "1.0.1", -1},
+		{"1.0.1", "1.0", 1},
+		{"2024.1", "2024.1-beta", -1},
+		{"release-2", "release-10", -1},
+		{"01", "1", 0},
+		{"v1", "1", 1},
+		{"alpha", "beta", -1},
+		{"", "1", -1},
+		{"", "", 0},
+	}
+	for _, test := range tests {
+		if actual := CompareVersionNames(test.a, test.b); actual != test.expected {
+			t.Errorf("CompareVersionNames(%q, %q) = %d, expected %d", test.a, test.b, actual, test.expected)
+		}
+	}
+}
@@ -6,6 +6,9 @@ const (
 	OpenAPI31Type ApiType = "openapi-3-1"
 	OpenAPI30Type ApiType = "openapi-3-0"
 	OpenAPI20Type ApiType = "openapi-2-0"
+	AsyncAPI2Type ApiType = "asyncapi-2"
+	AsyncAPI3Type ApiType = "asyncapi-3"
+	GraphQLType   ApiType = "graphql"
 )
 
 type VersionDocuments struct {
@@ -20,6 +23,7 @@ type PublishedDocument struct {
 	Labels      []string `json:"labels,omitempty"`
 	Description string   `json:"description,omitempty"`
 	Filename    string   `json:"filename"`
+	Checksum    string   `json:"checksum,omitempty"` // checksum of the document content calculated by APIHUB, optional
 }
 
 type LintedDocumentStatus string
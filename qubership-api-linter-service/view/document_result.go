@@ -3,6 +3,7 @@ package view
 type DocumentResult struct {
 	Ruleset           Ruleset           `json:"ruleset"`
 	Issues            []ValidationIssue `json:"issues"`
+	WaivedIssues      []ValidationIssue `json:"waivedIssues,omitempty"`
 	ValidatedDocument ValidatedDocument `json:"document"`
 }
 
@@ -11,4 +12,21 @@ type ValidationIssue struct {
 	Code     string   `json:"code,omitempty"`
 	Severity string   `json:"severity,omitempty"`
 	Message  string   `json:"message,omitempty"`
+	// Description of the rule from the ruleset rule catalog
+	Description string `json:"description,omitempty"`
+	// Id of the waiver which accepts the issue
+	WaiverId string `json:"waiverId,omitempty"`
+	// Range of the issue in the document, nil if the linter does not report it
+	Range *IssueRange `json:"range,omitempty"`
+}
+
+// IssueRange is the issue location in the document, lines and characters are zero-based
+type IssueRange struct {
+	Start IssuePosition `json:"start"`
+	End   IssuePosition `json:"end"`
+}
+
+type IssuePosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
 }
@@ -0,0 +1,16 @@
+package view
+
+import "time"
+
+type DraftDocument struct {
+	FileName string
+	Data     []byte
+}
+
+type DraftValidationSummary struct {
+	DraftId   string    `json:"draftId"`
+	PackageId string    `json:"packageId"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	ValidationSummaryForVersion
+}
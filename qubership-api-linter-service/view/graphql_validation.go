@@ -0,0 +1,19 @@
+package view
+
+type GraphqlReport struct {
+	Errors []GraphqlOutputItem `json:"errors"`
+}
+
+type GraphqlOutputItem struct {
+	Message  string `json:"message"`
+	Rule     string `json:"rule"`
+	Location struct {
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+		File   string `json:"file"`
+	} `json:"location"`
+}
+
+type GraphqlResultSummary struct {
+	ErrorCount int `json:"errorCount"`
+}
@@ -0,0 +1,16 @@
+package view
+
+// AdHocLintRequest describes the document which is linted outside of APIHUB version publication
+type AdHocLintRequest struct {
+	FileName  string
+	Data      []byte
+	ApiType   ApiType        // optional, detected by the document content if empty
+	RulesetId string         // optional, active ruleset for the api type is used by default
+	Ruleset   *InlineRuleset // optional, mutually exclusive with RulesetId
+}
+
+type InlineRuleset struct {
+	Linter   Linter
+	FileName string
+	Data     []byte
+}
@@ -0,0 +1,41 @@
+package view
+
+// VersionLintDiff classifies the issues of the version as introduced, fixed or unchanged comparing to the previous version
+type VersionLintDiff struct {
+	PackageId         string             `json:"packageId"`
+	Version           string             `json:"version"`
+	PreviousPackageId string             `json:"previousPackageId"`
+	PreviousVersion   string             `json:"previousVersion"`
+	Summary           LintDiffSummary    `json:"summary"`
+	Documents         []DocumentLintDiff `json:"documents"`
+}
+
+type LintDiffSummary struct {
+	Introduced IssuesSummary `json:"introduced"`
+	Fixed      IssuesSummary `json:"fixed"`
+	Unchanged  IssuesSummary `json:"unchanged"`
+}
+
+type DocumentLintDiff struct {
+	Slug              string             `json:"slug"`
+	DocumentName      string             `json:"documentName"`
+	ApiType           ApiType            `json:"apiType"`
+	Status            DocumentDiffStatus `json:"status"`
+	RulesetId         string             `json:"rulesetId,omitempty"`
+	PreviousRulesetId string             `json:"previousRulesetId,omitempty"`
+	Details           string             `json:"details,omitempty"`
+	Summary           LintDiffSummary    `json:"summary"`
+	Introduced        []ValidationIssue  `json:"introduced"`
+	Fixed             []ValidationIssue  `json:"fixed"`
+}
+
+type DocumentDiffStatus string
+
+const (
+	DocumentAdded     DocumentDiffStatus = "added"
+	DocumentRemoved   DocumentDiffStatus = "removed"
+	DocumentUnchanged DocumentDiffStatus = "unchanged"
+	DocumentChanged   DocumentDiffStatus = "changed"
+	// issues of the document are unknown because lint of the document failed in one of the versions
+	DocumentNotCompared DocumentDiffStatus = "notCompared"
+)
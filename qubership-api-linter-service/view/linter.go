@@ -4,6 +4,8 @@ type Linter string
 
 const (
 	SpectralLinter Linter = "spectral"
+	VacuumLinter   Linter = "vacuum"
+	GraphqlLinter  Linter = "graphql-schema-linter"
 
 	UnknownLinter Linter = "unknown"
 )
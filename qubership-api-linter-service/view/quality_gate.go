@@ -0,0 +1,41 @@
+package view
+
+import "time"
+
+// QualityGatePolicy lists the conditions the version lint result must satisfy, empty conditions are not checked
+type QualityGatePolicy struct {
+	MaxErrors   *int `json:"maxErrors,omitempty"`
+	MaxWarnings *int `json:"maxWarnings,omitempty"`
+	// no issues introduced comparing to the previous version
+	NoNewErrors   bool `json:"noNewErrors"`
+	NoNewWarnings bool `json:"noNewWarnings"`
+}
+
+type QualityGate struct {
+	Scope     QualityGateScope  `json:"scope"`
+	ScopeId   string            `json:"scopeId"`
+	Policy    QualityGatePolicy `json:"policy"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	UpdatedBy string            `json:"updatedBy"`
+}
+
+type QualityGateScope string
+
+const (
+	QualityGateScopeRuleset QualityGateScope = "ruleset"
+	QualityGateScopePackage QualityGateScope = "package"
+)
+
+type QualityGateStatus string
+
+const (
+	QualityGatePassed QualityGateStatus = "passed"
+	QualityGateFailed QualityGateStatus = "failed"
+	// QualityGateError means that the verdict could not be evaluated
+	QualityGateError QualityGateStatus = "error"
+)
+
+type QualityGateVerdict struct {
+	Status  QualityGateStatus `json:"status"`
+	Reasons []string          `json:"reasons,omitempty"`
+}
@@ -0,0 +1,35 @@
+package view
+
+import "time"
+
+type RelintCampaign struct {
+	Id                 string              `json:"id"`
+	RulesetId          string              `json:"rulesetId"`
+	Status             LintedVersionStatus `json:"status"`
+	Details            string              `json:"details,omitempty"`
+	VersionsPerPackage int                 `json:"versionsPerPackage"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	CreatedBy          string              `json:"createdBy"`
+	UpdatedAt          time.Time           `json:"updatedAt"`
+	Progress           RelintProgress      `json:"progress"`
+}
+
+// RelintProgress is the number of campaign versions in each status
+type RelintProgress struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Enqueued  int `json:"enqueued"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+type RelintVersionStatus string
+
+const (
+	RelintVersionPending  RelintVersionStatus = "pending"
+	RelintVersionEnqueued RelintVersionStatus = "enqueued"
+	RelintVersionSuccess  RelintVersionStatus = "success"
+	RelintVersionError    RelintVersionStatus = "error"
+	RelintVersionSkipped  RelintVersionStatus = "skipped"
+)
@@ -0,0 +1,18 @@
+package view
+
+type RuleCatalog struct {
+	Ruleset Ruleset           `json:"ruleset"`
+	Rules   []RuleDescription `json:"rules"`
+	// Unresolved contains extends and ruleset files which rules could not be described, e.g. remote rulesets or JavaScript rulesets
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+type RuleDescription struct {
+	Code             string   `json:"code"`
+	Description      string   `json:"description,omitempty"`
+	Severity         string   `json:"severity,omitempty"`
+	Given            []string `json:"given,omitempty"`
+	DocumentationUrl string   `json:"documentationUrl,omitempty"`
+	// Source is the built-in ruleset name (e.g. spectral:oas), the id of the stored ruleset or the path of the ruleset bundle file which defines the rule
+	Source string `json:"source"`
+}
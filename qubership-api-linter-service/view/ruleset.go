@@ -11,6 +11,22 @@ type Ruleset struct {
 	ApiType      ApiType       `json:"apiType"`
 	CreatedAt    time.Time     `json:"createdAt"`
 	CanBeDeleted bool          `json:"canBeDeleted"`
+	Parents      []string      `json:"parents,omitempty"`
+	Lineage      string        `json:"lineage"` // rulesets with the same name and api type are revisions of one lineage
+	Revision     int           `json:"revision"`
+}
+
+// CreateRulesetRequest describes the uploaded ruleset, the ruleset file could be a zip/tar bundle with additional files
+type CreateRulesetRequest struct {
+	Name          string
+	ApiType       ApiType
+	Linter        Linter
+	FileName      string
+	Data          []byte
+	MainFile      string            // optional, path of the main ruleset file in the bundle
+	Parents       []string          // optional, ids or names of the extended rulesets
+	FunctionFiles map[string][]byte // optional, custom functions: file name -> data
+	NewRevision   bool              // optional, creates the next revision if a ruleset with the name exists
 }
 
 type RulesetStatus string
@@ -21,11 +37,88 @@ const (
 )
 
 type ActivationHistoryResponse struct {
-	Id                string             `json:"id"`
-	ActivationHistory []ActivationRecord `json:"activationHistory"`
+	Id                string               `json:"id"`
+	ActivationHistory []ActivationRecord   `json:"activationHistory"`
+	Schedules         []ActivationSchedule `json:"schedules"`
 }
 
 type ActivationRecord struct {
-	ActiveFrom time.Time  `json:"activeFrom"`
-	ActiveTo   *time.Time `json:"activeTo,omitempty"`
+	ActiveFrom     time.Time      `json:"activeFrom"`
+	ActiveTo       *time.Time     `json:"activeTo,omitempty"`
+	ActivatedBy    string         `json:"activatedBy,omitempty"`
+	DeactivatedBy  string         `json:"deactivatedBy,omitempty"`
+	ActivationType ActivationType `json:"activationType"`
+	ScheduledBy    string         `json:"scheduledBy,omitempty"`
+}
+
+type ActivationType string
+
+const (
+	ActivationTypeManual    ActivationType = "manual"
+	ActivationTypeScheduled ActivationType = "scheduled"
+	ActivationTypeRollback  ActivationType = "rollback"
+)
+
+// ActivateRulesetRequest is optional, the ruleset is activated immediately if ActivateAt is not set.
+// Relint starts re-lint of recently linted versions once the ruleset is activated.
+type ActivateRulesetRequest struct {
+	ActivateAt *time.Time `json:"activateAt,omitempty"`
+	Relint     bool       `json:"relint,omitempty"`
+}
+
+type ActivationSchedule struct {
+	Id          string                   `json:"id"`
+	RulesetId   string                   `json:"rulesetId"`
+	ActivateAt  time.Time                `json:"activateAt"`
+	Status      ActivationScheduleStatus `json:"status"`
+	Details     string                   `json:"details,omitempty"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	CreatedBy   string                   `json:"createdBy"`
+	CancelledAt *time.Time               `json:"cancelledAt,omitempty"`
+	CancelledBy string                   `json:"cancelledBy,omitempty"`
+	ExecutedAt  *time.Time               `json:"executedAt,omitempty"`
+	Relint      bool                     `json:"relint"`
+}
+
+type ActivationScheduleStatus string
+
+const (
+	ActivationScheduled         ActivationScheduleStatus = "scheduled"
+	ActivationScheduleCompleted ActivationScheduleStatus = "completed"
+	ActivationScheduleCancelled ActivationScheduleStatus = "cancelled"
+	ActivationScheduleFailed    ActivationScheduleStatus = "failed"
+)
+
+type RulesetDryRunRequest struct {
+	PackageId string `json:"packageId"`
+	Version   string `json:"version"`
+}
+
+type RulesetDryRunResult struct {
+	Ruleset   Ruleset                 `json:"ruleset"`
+	PackageId string                  `json:"packageId"`
+	Version   string                  `json:"version"`
+	Documents []RulesetDryRunDocument `json:"documents"`
+}
+
+type RulesetDryRunDocument struct {
+	ValidationDocument
+	Issues []ValidationIssue `json:"issues,omitempty"`
+}
+
+type RulesetDiff struct {
+	Base            Ruleset           `json:"base"`
+	Target          Ruleset           `json:"target"`
+	Added           []RulesetDiffRule `json:"added"`
+	Removed         []RulesetDiffRule `json:"removed"`
+	SeverityChanged []RulesetDiffRule `json:"severityChanged"`
+	Modified        []RulesetDiffRule `json:"modified"` // rules with changed definition and the same severity
+	ExtendsAdded    []string          `json:"extendsAdded"`
+	ExtendsRemoved  []string          `json:"extendsRemoved"`
+}
+
+type RulesetDiffRule struct {
+	Name             string `json:"name"`
+	Severity         string `json:"severity,omitempty"`
+	PreviousSeverity string `json:"previousSeverity,omitempty"`
 }
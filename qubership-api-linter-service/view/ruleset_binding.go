@@ -0,0 +1,32 @@
+package view
+
+import "time"
+
+type RulesetBinding struct {
+	PackageId string    `json:"packageId"`
+	ApiType   ApiType   `json:"apiType"`
+	Ruleset   Ruleset   `json:"ruleset"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy"`
+}
+
+type RulesetBindingsResponse struct {
+	PackageId string           `json:"packageId"`
+	Bindings  []RulesetBinding `json:"bindings"`
+}
+
+type RulesetBindingHistoryRecord struct {
+	ApiType    ApiType    `json:"apiType"`
+	RulesetId  string     `json:"rulesetId"`
+	ActiveFrom time.Time  `json:"activeFrom"`
+	ActiveTo   *time.Time `json:"activeTo,omitempty"`
+}
+
+type RulesetBindingHistoryResponse struct {
+	PackageId         string                        `json:"packageId"`
+	ActivationHistory []RulesetBindingHistoryRecord `json:"activationHistory"`
+}
+
+type BindRulesetRequest struct {
+	RulesetId string `json:"rulesetId"`
+}
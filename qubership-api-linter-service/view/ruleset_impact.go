@@ -0,0 +1,39 @@
+package view
+
+import "time"
+
+type RulesetImpactRequest struct {
+	SampleSize  int  `json:"sampleSize"`
+	AllPackages bool `json:"allPackages"`
+}
+
+type RulesetImpactReport struct {
+	Id                string                 `json:"id"`
+	RulesetId         string                 `json:"rulesetId"`
+	Status            LintedVersionStatus    `json:"status"`
+	Details           string                 `json:"details,omitempty"`
+	CreatedAt         time.Time              `json:"createdAt"`
+	CreatedBy         string                 `json:"createdBy"`
+	UpdatedAt         time.Time              `json:"updatedAt"`
+	PackagesTotal     int                    `json:"packagesTotal"`
+	PackagesProcessed int                    `json:"packagesProcessed"`
+	Total             *RulesetImpactSummary  `json:"total,omitempty"`
+	Packages          []RulesetImpactPackage `json:"packages"`
+}
+
+type RulesetImpactPackage struct {
+	PackageId        string `json:"packageId"`
+	Version          string `json:"version"`
+	CurrentRulesetId string `json:"currentRulesetId,omitempty"`
+	RulesetImpactSummary
+	DocumentsCount  int    `json:"documentsCount"`
+	FailedDocuments int    `json:"failedDocuments,omitempty"`
+	Details         string `json:"details,omitempty"`
+}
+
+// RulesetImpactSummary is issue counts of the currently used and the candidate rulesets, Delta = Candidate - Current
+type RulesetImpactSummary struct {
+	Current   IssuesSummary `json:"current"`
+	Candidate IssuesSummary `json:"candidate"`
+	Delta     IssuesSummary `json:"delta"`
+}
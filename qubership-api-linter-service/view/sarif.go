@@ -0,0 +1,109 @@
+package view
+
+// SARIF 2.1.0 log, only the properties filled by the service are declared
+
+const (
+	SarifVersion   = "2.1.0"
+	SarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	SarifMediaType = "application/sarif+json"
+)
+
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool        SarifTool              `json:"tool"`
+	Invocations []SarifInvocation      `json:"invocations,omitempty"`
+	Artifacts   []SarifArtifact        `json:"artifacts,omitempty"`
+	Results     []SarifResult          `json:"results"`
+	Properties  map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifTool struct {
+	Driver SarifToolComponent `json:"driver"`
+}
+
+type SarifToolComponent struct {
+	Name    string                     `json:"name"`
+	Version string                     `json:"version,omitempty"`
+	Rules   []SarifReportingDescriptor `json:"rules,omitempty"`
+}
+
+type SarifReportingDescriptor struct {
+	Id                   string                       `json:"id"`
+	ShortDescription     *SarifMessage                `json:"shortDescription,omitempty"`
+	HelpUri              string                       `json:"helpUri,omitempty"`
+	DefaultConfiguration *SarifReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{}       `json:"properties,omitempty"`
+}
+
+type SarifReportingConfiguration struct {
+	// Enabled is false for the rules turned off in the ruleset, nil means enabled
+	Enabled *bool  `json:"enabled,omitempty"`
+	Level   string `json:"level,omitempty"`
+}
+
+type SarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []SarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type SarifNotification struct {
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifArtifact struct {
+	Location   SarifArtifactLocation  `json:"location"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifResult struct {
+	RuleId       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      SarifMessage       `json:"message"`
+	Locations    []SarifLocation    `json:"locations"`
+	Suppressions []SarifSuppression `json:"suppressions,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	Uri   string `json:"uri"`
+	Index int    `json:"index"`
+}
+
+// SarifRegion lines and columns are one-based
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type SarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type SarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
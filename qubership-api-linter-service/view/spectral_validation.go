@@ -30,21 +30,12 @@ type SpectralDocumentValidationEntity struct {
 }
 
 type SpectralOutputItem struct {
-	Code     string   `json:"code"`
-	Path     []string `json:"path"`
-	Message  string   `json:"message"`
-	Severity int      `json:"severity"`
-	Range    struct {
-		Start struct {
-			Line      int `json:"line"`
-			Character int `json:"character"`
-		} `json:"start"`
-		End struct {
-			Line      int `json:"line"`
-			Character int `json:"character"`
-		} `json:"end"`
-	} `json:"range"`
-	Source string `json:"source"`
+	Code     string     `json:"code"`
+	Path     []string   `json:"path"`
+	Message  string     `json:"message"`
+	Severity int        `json:"severity"`
+	Range    IssueRange `json:"range"`
+	Source   string     `json:"source"`
 }
 
 func ConvertSpectralSeverityToString(severity int) string {
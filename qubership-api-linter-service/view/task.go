@@ -9,3 +9,11 @@ const (
 	TaskStatusSuccess        TaskStatus = "success"
 	TaskStatusError          TaskStatus = "error"
 )
+
+// Priorities of the lint tasks, tasks with higher priority are taken first.
+// Waiting tasks are aging, so tasks with low priority are taken eventually.
+const (
+	TaskPriorityRelint  = -10
+	TaskPriorityPublish = 0
+	TaskPriorityUser    = 10
+)
@@ -14,14 +14,6 @@
 
 package view
 
-type ValidationResult struct {
-}
-
-type ValidationData struct {
-	DataHash string
-	Data     []byte
-}
-
 type VacuumReport struct {
 	ResultSet  VacuumResultSet        `json:"resultSet"`
 	Statistics map[string]interface{} `json:"statistics"`
@@ -29,7 +21,7 @@ type VacuumReport struct {
 
 type VacuumResultSet struct {
 	VacuumResultSummary
-	Results []interface{} `json:"results"`
+	Results []VacuumOutputItem `json:"results"`
 }
 
 type VacuumResultSummary struct {
@@ -39,18 +31,24 @@ type VacuumResultSummary struct {
 	HintCount    int `json:"hintCount"`
 }
 
-type DocumentValidationEntity struct {
-	Filename        string
-	Summary         map[string]interface{}
-	Report          []interface{}
-	CalculationTime int64
-	Details         string
+type VacuumOutputItem struct {
+	Message      string     `json:"message"`
+	Path         string     `json:"path"`
+	RuleId       string     `json:"ruleId"`
+	RuleSeverity string     `json:"ruleSeverity"`
+	Range        IssueRange `json:"range"`
 }
 
-type OperationValidationEntity struct {
-	DataHash        string
-	Summary         map[string]interface{}
-	Report          []interface{}
-	CalculationTime int64
-	Details         string
+func ConvertVacuumSeverityToString(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warn", "warning":
+		return "warning"
+	case "info":
+		return "info"
+	case "hint":
+		return "hint"
+	}
+	return "unknown"
 }
@@ -61,6 +61,8 @@ type ValidationSummaryForVersion struct {
 	Details   string               `json:"details,omitempty"`
 	Documents []ValidationDocument `json:"documents,omitempty"`
 	Rulesets  []Ruleset            `json:"rulesets,omitempty"`
+	// nil if no quality gate policy is applicable to the version
+	QualityGate *QualityGateVerdict `json:"qualityGate,omitempty"`
 }
 
 type ValidationDocument struct {
@@ -71,6 +73,10 @@ type ValidationDocument struct {
 	DocumentName  string               `json:"documentName"`
 	RulesetId     string               `json:"rulesetId"`
 	IssuesSummary *IssuesSummary       `json:"issuesSummary,omitempty"`
+	// waived issues are not included to IssuesSummary
+	WaivedIssuesSummary *IssuesSummary `json:"waivedIssuesSummary,omitempty"`
+	// issues suppressed by the inline extension by rule code, they are removed from the lint result
+	SuppressedIssues map[string]int `json:"suppressedIssues,omitempty"`
 }
 
 type IssuesSummary struct {
@@ -93,3 +99,17 @@ func (i *IssuesSummary) Append(add IssuesSummary) {
 	i.Warning += add.Warning
 	i.Info += add.Info
 }
+
+// AddIssue counts the issue of the severity
+func (i *IssuesSummary) AddIssue(severity string) {
+	switch severity {
+	case "error":
+		i.Error++
+	case "warning":
+		i.Warning++
+	case "info":
+		i.Info++
+	case "hint":
+		i.Hint++
+	}
+}
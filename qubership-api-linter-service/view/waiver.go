@@ -0,0 +1,38 @@
+package view
+
+import "time"
+
+// IssueWaiver accepts the issues of the package which match all the specified conditions
+type IssueWaiver struct {
+	Id        string `json:"id"`
+	PackageId string `json:"packageId"`
+	// inclusive version range, versions are compared in natural order
+	VersionFrom string `json:"versionFrom,omitempty"`
+	VersionTo   string `json:"versionTo,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	RuleCode    string `json:"ruleCode,omitempty"`
+	// JSON pointer, issues at the path and below are waived, "*" segment matches any segment
+	Path          string     `json:"path,omitempty"`
+	Justification string     `json:"justification"`
+	ExpiresAt     time.Time  `json:"expiresAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	CreatedBy     string     `json:"createdBy"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty"`
+	RevokedBy     string     `json:"revokedBy,omitempty"`
+	Active        bool       `json:"active"`
+}
+
+type CreateIssueWaiverRequest struct {
+	VersionFrom   string    `json:"versionFrom,omitempty"`
+	VersionTo     string    `json:"versionTo,omitempty"`
+	Slug          string    `json:"slug,omitempty"`
+	RuleCode      string    `json:"ruleCode,omitempty"`
+	Path          string    `json:"path,omitempty"`
+	Justification string    `json:"justification"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+type IssueWaiversResponse struct {
+	PackageId string        `json:"packageId"`
+	Waivers   []IssueWaiver `json:"waivers"`
+}